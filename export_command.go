@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go-touch/internal/stats"
+	"go-touch/internal/types"
+	"io"
+	"os"
+)
+
+// runExport writes the whole history to stdout or a file
+func runExport(args []string, output io.Writer) error {
+	flags := flag.NewFlagSet("gotouch export", flag.ContinueOnError)
+	flags.SetOutput(output)
+	configPath := flags.String("config", "", "Path to config file")
+	formatName := flags.String("format", "csv", "Export format: csv, json or markdown")
+	outputPath := flags.String("output", "", "Write to a file instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+	format, err := stats.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	_, history, err := loadHistory(*configPath)
+	if err != nil {
+		return err
+	}
+
+	if *outputPath == "" {
+		return stats.Export(output, history, format)
+	}
+	file, err := os.Create(*outputPath)
+	if err != nil {
+		return err
+	}
+	if err := stats.Export(file, history, format); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(output, "Exported %d sessions to %s\n", len(history.Sessions), *outputPath)
+	return nil
+}
+
+// runImport adds the sessions of an export file to the history, skipping
+// sessions that are already recorded
+func runImport(args []string, output io.Writer) error {
+	flags := flag.NewFlagSet("gotouch import", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: gotouch import [--config path] <file>")
+		fmt.Fprintln(flags.Output(), "Reads GoTouch CSV or JSON exports and Monkeytype CSV result exports.")
+		flags.PrintDefaults()
+	}
+	configPath := flags.String("config", "", "Path to config file")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("import needs exactly one file")
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	imported, err := stats.Import(file)
+	file.Close()
+	if err != nil {
+		return fmt.Errorf("%s: %w", flags.Arg(0), err)
+	}
+
+	store, err := openHistory(*configPath)
+	if err != nil {
+		return err
+	}
+	// Merging under the store's lock keeps sessions finished meanwhile; the
+	// write backs up the old history
+	var added, skipped int
+	err = store.Update(func(history *types.UserStats) bool {
+		*history, added, skipped = stats.Merge(*history, imported)
+		return added > 0
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save stats: %w", err)
+	}
+	fmt.Fprintf(output, "Imported %d sessions, skipped %d already recorded\n", added, skipped)
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"go-touch/internal/stats"
+	"go-touch/internal/types"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	configPath, statsPath := writeStatsConfig(t, t.TempDir())
+	store, err := stats.Open(statsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, wpm := range []float32{40, 50} {
+		if err := store.Append(types.TypingSession{Date: time.Now().Add(time.Duration(wpm) * time.Second), WPM: wpm}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	exportPath := filepath.Join(t.TempDir(), "history.csv")
+	var out bytes.Buffer
+	if err := runExport([]string{"--config", configPath, "--format", "csv", "--output", exportPath}, &out); err != nil {
+		t.Fatalf("runExport() unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Exported 2 sessions") {
+		t.Errorf("runExport() output = %q", out.String())
+	}
+
+	// Importing into a fresh history adds everything, importing again adds nothing
+	freshConfig, freshStats := writeStatsConfig(t, t.TempDir())
+	for i, want := range []string{"Imported 2 sessions, skipped 0", "Imported 0 sessions, skipped 2"} {
+		out.Reset()
+		if err := runImport([]string{"--config", freshConfig, exportPath}, &out); err != nil {
+			t.Fatalf("runImport() unexpected error: %v", err)
+		}
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("runImport() #%d output = %q, want %q", i+1, out.String(), want)
+		}
+	}
+
+	fresh, err := stats.Open(freshStats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	history, err := fresh.Load()
+	if err != nil || len(history.Sessions) != 2 {
+		t.Errorf("imported history = %+v, %v, want 2 sessions", history.Sessions, err)
+	}
+}
+
+func TestRunImport_NeedsFile(t *testing.T) {
+	if err := runImport(nil, &bytes.Buffer{}); err == nil {
+		t.Error("runImport() without a file expected an error")
+	}
+}
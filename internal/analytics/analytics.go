@@ -0,0 +1,289 @@
+package analytics
+
+import (
+	"fmt"
+	"go-touch/internal/types"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// MinSessions is the history needed before a curve is fitted
+	MinSessions = 8
+	// PlateauSessions is how many sessions without a new high of the moving
+	// average count as a plateau
+	PlateauSessions = 20
+	// plateauWindow is the number of sessions in the moving average watched for a plateau
+	plateauWindow = 5
+	// anomalyThreshold is how many robust standard deviations from the median
+	// make a session abnormal
+	anomalyThreshold = 3.5
+	// maxProjectedSessions bounds a projection; targets further away are out of reach
+	maxProjectedSessions = 5000
+	// rateWindow is the recent span used to measure how often the user practises
+	rateWindow = 30 * 24 * time.Hour
+)
+
+// Curve is a learning curve WPM = Intercept + Slope * ln(n) over the session
+// number n, counted from 1. Typing speed grows fast at first and ever more
+// slowly, which a logarithm follows closely.
+type Curve struct {
+	Intercept float64 `json:"intercept"`
+	Slope     float64 `json:"slope"`
+	R2        float64 `json:"r2"` // Share of the WPM variance the curve explains, 0-1
+}
+
+// At returns the WPM the curve predicts for session n
+func (c Curve) At(n float64) float64 {
+	return c.Intercept + c.Slope*math.Log(n)
+}
+
+// SessionsToReach returns the session number at which the curve reaches wpm.
+// It reports false when the curve is flat or falling.
+func (c Curve) SessionsToReach(wpm float64) (float64, bool) {
+	if c.Slope <= 0 {
+		return 0, false
+	}
+	return math.Exp((wpm - c.Intercept) / c.Slope), true
+}
+
+// FitCurve fits a learning curve to WPMs in session order by least squares
+func FitCurve(wpms []float64) (Curve, bool) {
+	if len(wpms) < MinSessions {
+		return Curve{}, false
+	}
+	n := float64(len(wpms))
+	var sumX, sumY, sumXX, sumXY float64
+	for i, y := range wpms {
+		x := math.Log(float64(i + 1))
+		sumX += x
+		sumY += y
+		sumXX += x * x
+		sumXY += x * y
+	}
+	curve := Curve{}
+	curve.Slope = (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+	curve.Intercept = (sumY - curve.Slope*sumX) / n
+
+	mean := sumY / n
+	var total, residual float64
+	for i, y := range wpms {
+		total += (y - mean) * (y - mean)
+		d := y - curve.At(float64(i+1))
+		residual += d * d
+	}
+	if total > 0 {
+		curve.R2 = 1 - residual/total
+	}
+	return curve, true
+}
+
+// SessionsSincePeak returns how many sessions ago the moving average of
+// window sessions last reached a new high
+func SessionsSincePeak(wpms []float64, window int) int {
+	if len(wpms) < window {
+		return 0
+	}
+	var sum, best float64
+	peak := 0
+	for i, wpm := range wpms {
+		sum += wpm
+		if i >= window {
+			sum -= wpms[i-window]
+		}
+		if i < window-1 {
+			continue
+		}
+		if avg := sum / float64(window); i == window-1 || avg > best {
+			best = avg
+			peak = i
+		}
+	}
+	return len(wpms) - 1 - peak
+}
+
+// Anomaly is a session far outside the user's usual range
+type Anomaly struct {
+	Session types.TypingSession `json:"session"`
+	Reason  string              `json:"reason"`
+}
+
+// Anomalies flags sessions whose WPM strays from the learning curve, or
+// whose accuracy strays from the median, by more than anomalyThreshold robust
+// deviations, such as a run abandoned half-way or typed with a stuck key.
+// Measuring WPM against the curve keeps the slow early sessions of a fast
+// learner from counting as abnormal.
+func Anomalies(sessions []types.TypingSession, curve Curve) []Anomaly {
+	if len(sessions) < MinSessions {
+		return nil
+	}
+	residuals := make([]float64, len(sessions))
+	accuracies := make([]float64, len(sessions))
+	for i, session := range sessions {
+		residuals[i] = float64(session.WPM) - curve.At(float64(i+1))
+		accuracies[i] = float64(session.Accuracy)
+	}
+	wpmMedian, wpmSpread := robustSpread(residuals)
+	accMedian, accSpread := robustSpread(accuracies)
+
+	var anomalies []Anomaly
+	for i, session := range sessions {
+		switch {
+		case wpmSpread > 0 && (residuals[i]-wpmMedian)/wpmSpread < -anomalyThreshold:
+			anomalies = append(anomalies, Anomaly{Session: session, Reason: "unusually slow"})
+		case wpmSpread > 0 && (residuals[i]-wpmMedian)/wpmSpread > anomalyThreshold:
+			anomalies = append(anomalies, Anomaly{Session: session, Reason: "unusually fast"})
+		case accSpread > 0 && (accuracies[i]-accMedian)/accSpread < -anomalyThreshold:
+			anomalies = append(anomalies, Anomaly{Session: session, Reason: "unusually inaccurate"})
+		}
+	}
+	return anomalies
+}
+
+// robustSpread returns the median and the median absolute deviation scaled
+// to match a standard deviation, which single outliers cannot drag along
+func robustSpread(values []float64) (median, spread float64) {
+	median = medianOf(values)
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - median)
+	}
+	return median, 1.4826 * medianOf(deviations)
+}
+
+func medianOf(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// Projection estimates when a target WPM will be reached
+type Projection struct {
+	Target     float64   `json:"target"`
+	Reached    bool      `json:"reached"`                // The curve is already at the target
+	OutOfReach bool      `json:"out_of_reach,omitempty"` // The curve is flat, falling or too slow to get there
+	Sessions   int       `json:"sessions,omitempty"`     // Sessions still needed
+	Date       time.Time `json:"date,omitzero"`          // Expected date at the recent practice rate
+}
+
+// Insights summarises the learning curve of a history
+type Insights struct {
+	Sessions   int         `json:"sessions"`
+	Ready      bool        `json:"ready"` // Enough sessions for a curve
+	Curve      Curve       `json:"curve"`
+	Trend      float64     `json:"trend"`      // WPM gained over the next 10 sessions on the curve
+	SincePeak  int         `json:"since_peak"` // Sessions since the moving average last improved
+	Plateau    bool        `json:"plateau"`    // No improvement in PlateauSessions sessions
+	Projection *Projection `json:"projection"` // Nil without a target
+	Anomalies  []Anomaly   `json:"anomalies"`
+}
+
+// Analyze fits the learning curve of the sessions, oldest first, and projects
+// when target WPM will be reached; a zero target skips the projection.
+// Drills are left out, their short repeated words would skew the curve.
+func Analyze(sessions []types.TypingSession, target float64, now time.Time) Insights {
+	var regular []types.TypingSession
+	for _, session := range sessions {
+		if !session.Drill {
+			regular = append(regular, session)
+		}
+	}
+	insights := Insights{Sessions: len(regular)}
+	wpms := make([]float64, len(regular))
+	for i, session := range regular {
+		wpms[i] = float64(session.WPM)
+	}
+
+	curve, ok := FitCurve(wpms)
+	if !ok {
+		return insights
+	}
+	insights.Ready = true
+	insights.Curve = curve
+	n := float64(len(wpms))
+	insights.Trend = curve.At(n+10) - curve.At(n)
+	insights.SincePeak = SessionsSincePeak(wpms, plateauWindow)
+	insights.Plateau = insights.SincePeak >= PlateauSessions
+	insights.Anomalies = Anomalies(regular, curve)
+
+	if target > 0 {
+		insights.Projection = project(curve, regular, target, now)
+	}
+	return insights
+}
+
+// project estimates the sessions and date needed to reach target on the
+// curve, spreading the sessions at the practice rate of the last rateWindow
+func project(curve Curve, sessions []types.TypingSession, target float64, now time.Time) *Projection {
+	n := float64(len(sessions))
+	if curve.At(n) >= target {
+		return &Projection{Target: target, Reached: true}
+	}
+	reach, ok := curve.SessionsToReach(target)
+	if !ok || reach-n > maxProjectedSessions {
+		return &Projection{Target: target, OutOfReach: true}
+	}
+	remaining := int(math.Ceil(reach - n))
+
+	// A history shorter than rateWindow is measured over its own span
+	span := rateWindow
+	if first := now.Sub(sessions[0].Date) + 24*time.Hour; first < span {
+		span = first
+	}
+	recent := 0
+	for _, session := range sessions {
+		if now.Sub(session.Date) < span {
+			recent++
+		}
+	}
+	projection := &Projection{Target: target, Sessions: remaining}
+	if recent > 0 {
+		perDay := float64(recent) / span.Hours() * 24
+		projection.Date = now.Add(time.Duration(float64(remaining) / perDay * float64(24*time.Hour)))
+	}
+	return projection
+}
+
+// Messages turns the insights into short lines of feedback, most important first
+func (i Insights) Messages() []string {
+	if !i.Ready {
+		return []string{fmt.Sprintf("Insights appear after %d sessions (%d so far)", MinSessions, i.Sessions)}
+	}
+
+	var messages []string
+	switch {
+	case i.Plateau:
+		messages = append(messages, fmt.Sprintf("Plateau: no improvement in the last %d sessions. Try harder text, a longer duration or slowing down for accuracy", i.SincePeak))
+	case i.Trend >= 0.5:
+		messages = append(messages, fmt.Sprintf("Improving: about +%.1f WPM over the next 10 sessions", i.Trend))
+	case i.Trend <= -0.5:
+		messages = append(messages, fmt.Sprintf("Slipping: about %.1f WPM over the next 10 sessions. Rest or ease off the difficulty", i.Trend))
+	default:
+		messages = append(messages, "Holding steady: speed is flat on your learning curve")
+	}
+
+	if p := i.Projection; p != nil {
+		switch {
+		case p.Reached:
+			messages = append(messages, fmt.Sprintf("Target: %.0f WPM reached, time to raise it", p.Target))
+		case p.OutOfReach:
+			messages = append(messages, fmt.Sprintf("Target: %.0f WPM is out of reach at the current pace", p.Target))
+		case !p.Date.IsZero():
+			messages = append(messages, fmt.Sprintf("Target: %.0f WPM in about %d sessions, around %s", p.Target, p.Sessions, p.Date.Format("Jan 2, 2006")))
+		default:
+			messages = append(messages, fmt.Sprintf("Target: %.0f WPM in about %d sessions", p.Target, p.Sessions))
+		}
+	}
+
+	if len(i.Anomalies) > 0 {
+		latest := i.Anomalies[len(i.Anomalies)-1]
+		messages = append(messages, fmt.Sprintf("Unusual: %d sessions stand out, the latest %s on %s",
+			len(i.Anomalies), latest.Reason, latest.Session.Date.Local().Format("Jan 2")))
+	}
+	return messages
+}
@@ -0,0 +1,201 @@
+package analytics
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+// curveSessions returns n daily sessions ending at now that follow
+// 30 + 10 ln(session) WPM exactly
+func curveSessions(n int, now time.Time) []types.TypingSession {
+	sessions := make([]types.TypingSession, n)
+	for i := range sessions {
+		sessions[i] = types.TypingSession{
+			Date:     now.AddDate(0, 0, i-n+1),
+			WPM:      float32(30 + 10*math.Log(float64(i+1))),
+			Accuracy: 95,
+		}
+	}
+	return sessions
+}
+
+func wpmsOf(sessions []types.TypingSession) []float64 {
+	wpms := make([]float64, len(sessions))
+	for i, session := range sessions {
+		wpms[i] = float64(session.WPM)
+	}
+	return wpms
+}
+
+func TestFitCurve(t *testing.T) {
+	wpms := make([]float64, 20)
+	for i := range wpms {
+		wpms[i] = 30 + 10*math.Log(float64(i+1))
+	}
+	curve, ok := FitCurve(wpms)
+	if !ok {
+		t.Fatal("FitCurve() not fitted")
+	}
+	if math.Abs(curve.Intercept-30) > 1e-6 || math.Abs(curve.Slope-10) > 1e-6 || math.Abs(curve.R2-1) > 1e-6 {
+		t.Errorf("FitCurve() = %+v, want intercept 30, slope 10 and R2 1", curve)
+	}
+
+	if _, ok := FitCurve(wpms[:MinSessions-1]); ok {
+		t.Errorf("FitCurve() fitted %d sessions, want at least %d", MinSessions-1, MinSessions)
+	}
+
+	flat, _ := FitCurve([]float64{50, 50, 50, 50, 50, 50, 50, 50})
+	if flat.Slope != 0 || flat.Intercept != 50 {
+		t.Errorf("FitCurve() flat = %+v, want slope 0 and intercept 50", flat)
+	}
+}
+
+func TestCurve_SessionsToReach(t *testing.T) {
+	curve := Curve{Intercept: 30, Slope: 10}
+	n, ok := curve.SessionsToReach(30 + 10*math.Log(50))
+	if !ok || math.Abs(n-50) > 1e-6 {
+		t.Errorf("SessionsToReach() = %v, %v, want 50", n, ok)
+	}
+	if _, ok := (Curve{Intercept: 60, Slope: -1}).SessionsToReach(70); ok {
+		t.Error("SessionsToReach() on a falling curve should fail")
+	}
+}
+
+func TestSessionsSincePeak(t *testing.T) {
+	tests := []struct {
+		name string
+		wpms []float64
+		want int
+	}{
+		{"too few", []float64{40, 41}, 0},
+		{"rising", []float64{40, 41, 42, 43, 44, 45, 46}, 0},
+		{"flat after a rise", []float64{40, 42, 44, 46, 48, 48, 48, 48, 48}, 2},
+		{"dip and recovery", []float64{50, 50, 50, 40, 40, 40, 60, 60}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SessionsSincePeak(tt.wpms, 3); got != tt.want {
+				t.Errorf("SessionsSincePeak() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnomalies(t *testing.T) {
+	now := time.Date(2025, 5, 20, 18, 0, 0, 0, time.UTC)
+	var sessions []types.TypingSession
+	for i := 0; i < 12; i++ {
+		sessions = append(sessions, types.TypingSession{Date: now.AddDate(0, 0, i), WPM: float32(50 + i%3), Accuracy: float32(95 + i%2)})
+	}
+	sessions[4].WPM = 12
+	sessions[9].Accuracy = 60
+
+	curve, _ := FitCurve(wpmsOf(sessions))
+	anomalies := Anomalies(sessions, curve)
+	if len(anomalies) != 2 {
+		t.Fatalf("Anomalies() = %+v, want 2", anomalies)
+	}
+	if anomalies[0].Session.WPM != 12 || anomalies[0].Reason != "unusually slow" {
+		t.Errorf("Anomalies()[0] = %+v, want the 12 WPM session flagged slow", anomalies[0])
+	}
+	if anomalies[1].Session.Accuracy != 60 || anomalies[1].Reason != "unusually inaccurate" {
+		t.Errorf("Anomalies()[1] = %+v, want the 60%% session flagged inaccurate", anomalies[1])
+	}
+
+	if got := Anomalies(sessions[:MinSessions-1], curve); got != nil {
+		t.Errorf("Anomalies() on a short history = %+v, want none", got)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	now := time.Date(2025, 5, 20, 18, 0, 0, 0, time.UTC)
+	sessions := curveSessions(20, now)
+
+	insights := Analyze(sessions, 0, now)
+	if !insights.Ready || insights.Sessions != 20 || insights.Projection != nil {
+		t.Fatalf("Analyze() = %+v, want a curve over 20 sessions and no projection", insights)
+	}
+	if insights.Trend <= 0 || insights.Plateau || len(insights.Anomalies) != 0 {
+		t.Errorf("Analyze() = %+v, want an improving trend without plateau or anomalies", insights)
+	}
+
+	// 30 + 10 ln(49.5) WPM is due half-way through session 50, 30 sessions
+	// away at one a day
+	target := 30 + 10*math.Log(49.5)
+	p := Analyze(sessions, target, now).Projection
+	if p == nil || p.Reached || p.OutOfReach || p.Sessions != 30 {
+		t.Fatalf("Analyze() projection = %+v, want 30 sessions to go", p)
+	}
+	if want := now.AddDate(0, 0, 30); p.Date.Sub(want).Abs() > time.Hour {
+		t.Errorf("Analyze() projection date = %v, want about %v", p.Date, want)
+	}
+
+	if p := Analyze(sessions, 40, now).Projection; p == nil || !p.Reached {
+		t.Errorf("Analyze() projection = %+v, want the target reached", p)
+	}
+	if p := Analyze(sessions, 200, now).Projection; p == nil || !p.OutOfReach {
+		t.Errorf("Analyze() projection = %+v, want the target out of reach", p)
+	}
+	if p := Analyze(sessions, target, now.AddDate(1, 0, 0)).Projection; p == nil || !p.Date.IsZero() || p.Sessions != 30 {
+		t.Errorf("Analyze() projection without recent practice = %+v, want sessions but no date", p)
+	}
+
+	drills := append(sessions[:5:5], types.TypingSession{Drill: true, WPM: 90}, types.TypingSession{Drill: true, WPM: 90})
+	if insights := Analyze(drills, 0, now); insights.Ready || insights.Sessions != 5 {
+		t.Errorf("Analyze() with drills = %+v, want 5 sessions and no curve", insights)
+	}
+}
+
+func TestAnalyze_Plateau(t *testing.T) {
+	now := time.Date(2025, 5, 20, 18, 0, 0, 0, time.UTC)
+	sessions := curveSessions(10, now)
+	for i := 0; i < PlateauSessions+5; i++ {
+		sessions = append(sessions, types.TypingSession{Date: now, WPM: 40, Accuracy: 95})
+	}
+
+	insights := Analyze(sessions, 0, now)
+	if !insights.Plateau || insights.SincePeak < PlateauSessions {
+		t.Errorf("Analyze() = %+v, want a plateau", insights)
+	}
+	if messages := insights.Messages(); !strings.HasPrefix(messages[0], "Plateau") {
+		t.Errorf("Messages() = %q, want the plateau first", messages)
+	}
+}
+
+func TestInsights_Messages(t *testing.T) {
+	date := time.Date(2025, 6, 19, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		insights Insights
+		want     []string
+	}{
+		{"not ready", Insights{Sessions: 3}, []string{"after 8 sessions (3 so far)"}},
+		{"improving", Insights{Ready: true, Trend: 2.3}, []string{"Improving: about +2.3 WPM"}},
+		{"slipping", Insights{Ready: true, Trend: -1.2}, []string{"Slipping: about -1.2 WPM"}},
+		{"steady", Insights{Ready: true, Trend: 0.1}, []string{"Holding steady"}},
+		{"projected", Insights{Ready: true, Projection: &Projection{Target: 70, Sessions: 30, Date: date}},
+			[]string{"70 WPM in about 30 sessions, around Jun 19, 2025"}},
+		{"no recent practice", Insights{Ready: true, Projection: &Projection{Target: 70, Sessions: 30}},
+			[]string{"70 WPM in about 30 sessions"}},
+		{"reached", Insights{Ready: true, Projection: &Projection{Target: 70, Reached: true}}, []string{"70 WPM reached"}},
+		{"out of reach", Insights{Ready: true, Projection: &Projection{Target: 200, OutOfReach: true}}, []string{"200 WPM is out of reach"}},
+		{"anomalies", Insights{Ready: true, Anomalies: []Anomaly{
+			{Session: types.TypingSession{Date: date}, Reason: "unusually fast"},
+			{Session: types.TypingSession{Date: date}, Reason: "unusually slow"},
+		}}, []string{"2 sessions stand out, the latest unusually slow"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := strings.Join(tt.insights.Messages(), "\n")
+			for _, want := range tt.want {
+				if !strings.Contains(messages, want) {
+					t.Errorf("Messages() = %q, want %q", messages, want)
+				}
+			}
+		})
+	}
+}
@@ -27,13 +27,32 @@ func DefaultConfig() types.Config {
 				TimeoutSeconds:       5,
 				MaxRetries:           1,
 			},
+			Difficulty: types.DifficultyConfig{
+				TargetAccuracyMin: 94,
+				TargetAccuracyMax: 97,
+			},
 		},
 		Ui: types.UiConfig{
-			Theme: "default",
+			Theme:          "default",
+			Backspace:      "unlimited",
+			ErrorMode:      "normal",
+			Layout:         "line",
+			LayoutLines:    3,
+			KeyboardLayout: "qwerty",
+			ShowKeyboard:   true,
+			PaceCaret:      "off",
+			PaceCaretWPM:   60,
+		},
+		Session: types.SessionConfig{
+			DefaultDuration:      "1m",
+			IdleThresholdSeconds: 10,
 		},
 		Stats: types.StatsConfig{
 			FileDir: statsPath,
 		},
+		Goals: types.GoalsConfig{
+			MinutesPerDay: 10,
+		},
 	}
 }
 
@@ -90,6 +109,7 @@ func LoadOrCreateConfig(explicitPath string) (*types.Config, string, error) {
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to load config from %s: %w", configPath, err)
 		}
+		fillDefaults(cfg)
 		return cfg, configPath, nil
 	}
 
@@ -111,6 +131,15 @@ func LoadOrCreateConfig(explicitPath string) (*types.Config, string, error) {
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to load newly created config: %w", err)
 	}
+	fillDefaults(cfg)
 
 	return cfg, configPath, nil
 }
+
+// fillDefaults sets the settings a config file may leave empty but that have
+// no sensible empty value, such as where the history is kept
+func fillDefaults(cfg *types.Config) {
+	if cfg.Stats.FileDir == "" {
+		cfg.Stats.FileDir = DefaultConfig().Stats.FileDir
+	}
+}
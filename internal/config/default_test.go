@@ -44,6 +44,10 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("DefaultConfig() Theme = %v, want 'default'", cfg.Ui.Theme)
 	}
 
+	if cfg.Session.DefaultDuration != "1m" {
+		t.Errorf("DefaultConfig() DefaultDuration = %v, want '1m'", cfg.Session.DefaultDuration)
+	}
+
 	if cfg.Stats.FileDir == "" {
 		t.Errorf("DefaultConfig() FileDir should not be empty")
 	}
@@ -190,6 +194,21 @@ func TestLoadOrCreateConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("empty stats path falls back to the default", func(t *testing.T) {
+		testConfigPath := filepath.Join(tmpDir, "empty-stats.yaml")
+		if err := os.WriteFile(testConfigPath, []byte("stats:\n  file_dir: \"\"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write test config: %v", err)
+		}
+
+		cfg, _, err := LoadOrCreateConfig(testConfigPath)
+		if err != nil {
+			t.Fatalf("LoadOrCreateConfig() error = %v", err)
+		}
+		if want := DefaultConfig().Stats.FileDir; cfg.Stats.FileDir != want {
+			t.Errorf("LoadOrCreateConfig() FileDir = %q, want %q", cfg.Stats.FileDir, want)
+		}
+	})
+
 	t.Run("error on invalid explicit path", func(t *testing.T) {
 		_, _, err := LoadOrCreateConfig("/nonexistent/path/config.yaml")
 		if err == nil {
@@ -0,0 +1,67 @@
+package keyboard
+
+// Finger assignments for the two common physical shapes
+var (
+	// ANSI: 13 number row keys, 13 top, 11 home, 10 bottom
+	ansiFingers = []string{
+		"0012335567888",
+		"0123355678888",
+		"01233556788",
+		"0123355678",
+	}
+	// ISO: 13 number row keys, 12 top, 12 home, 11 bottom with the extra key left of the bottom row
+	isoFingers = []string{
+		"0012335567888",
+		"012335567888",
+		"012335567888",
+		"00123355678",
+	}
+)
+
+// builtinOrder lists the built-in layouts in display order
+var builtinOrder = []string{"qwerty", "qwertz", "azerty", "dvorak", "colemak", "colemak_dh", "workman"}
+
+var builtins = map[string]Definition{
+	"qwerty": {
+		Name:    "QWERTY",
+		Rows:    []string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"},
+		Shifted: []string{"~!@#$%^&*()_+", "QWERTYUIOP{}|", "ASDFGHJKL:\"", "ZXCVBNM<>?"},
+		Fingers: ansiFingers,
+	},
+	"qwertz": {
+		Name:    "QWERTZ",
+		Rows:    []string{"^1234567890ß´", "qwertzuiopü+", "asdfghjklöä#", "<yxcvbnm,.-"},
+		Shifted: []string{"°!\"§$%&/()=?`", "QWERTZUIOPÜ*", "ASDFGHJKLÖÄ'", ">YXCVBNM;:_"},
+		Fingers: isoFingers,
+	},
+	"azerty": {
+		Name:    "AZERTY",
+		Rows:    []string{"²&é\"'(-è_çà)=", "azertyuiop^$", "qsdfghjklmù*", "<wxcvbn,;:!"},
+		Shifted: []string{"³1234567890°+", "AZERTYUIOP¨£", "QSDFGHJKLM%µ", ">WXCVBN?./§"},
+		Fingers: isoFingers,
+	},
+	"dvorak": {
+		Name:    "Dvorak",
+		Rows:    []string{"`1234567890[]", "',.pyfgcrl/=\\", "aoeuidhtns-", ";qjkxbmwvz"},
+		Shifted: []string{"~!@#$%^&*(){}", "\"<>PYFGCRL?+|", "AOEUIDHTNS_", ":QJKXBMWVZ"},
+		Fingers: ansiFingers,
+	},
+	"colemak": {
+		Name:    "Colemak",
+		Rows:    []string{"`1234567890-=", "qwfpgjluy;[]\\", "arstdhneio'", "zxcvbkm,./"},
+		Shifted: []string{"~!@#$%^&*()_+", "QWFPGJLUY:{}|", "ARSTDHNEIO\"", "ZXCVBKM<>?"},
+		Fingers: ansiFingers,
+	},
+	"colemak_dh": {
+		Name:    "Colemak-DH",
+		Rows:    []string{"`1234567890-=", "qwfpbjluy;[]\\", "arstgmneio'", "zxcdvkh,./"},
+		Shifted: []string{"~!@#$%^&*()_+", "QWFPBJLUY:{}|", "ARSTGMNEIO\"", "ZXCDVKH<>?"},
+		Fingers: ansiFingers,
+	},
+	"workman": {
+		Name:    "Workman",
+		Rows:    []string{"`1234567890-=", "qdrwbjfup;[]\\", "ashtgyneoi'", "zxmcvkl,./"},
+		Shifted: []string{"~!@#$%^&*()_+", "QDRWBJFUP:{}|", "ASHTGYNEOI\"", "ZXMCVKL<>?"},
+		Fingers: ansiFingers,
+	},
+}
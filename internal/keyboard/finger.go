@@ -0,0 +1,108 @@
+package keyboard
+
+// Finger identifies a finger in touch typing, numbered left to right
+type Finger int
+
+const (
+	LeftPinky Finger = iota
+	LeftRing
+	LeftMiddle
+	LeftIndex
+	Thumb
+	RightIndex
+	RightMiddle
+	RightRing
+	RightPinky
+)
+
+// Fingers lists all fingers left to right
+var Fingers = []Finger{LeftPinky, LeftRing, LeftMiddle, LeftIndex, Thumb, RightIndex, RightMiddle, RightRing, RightPinky}
+
+// Hand identifies the hand a finger belongs to
+type Hand int
+
+const (
+	LeftHand Hand = iota
+	RightHand
+	EitherHand // Thumbs on the space bar
+)
+
+func (f Finger) String() string {
+	switch f {
+	case LeftPinky:
+		return "left pinky"
+	case LeftRing:
+		return "left ring"
+	case LeftMiddle:
+		return "left middle"
+	case LeftIndex:
+		return "left index"
+	case Thumb:
+		return "thumb"
+	case RightIndex:
+		return "right index"
+	case RightMiddle:
+		return "right middle"
+	case RightRing:
+		return "right ring"
+	case RightPinky:
+		return "right pinky"
+	default:
+		return "unknown"
+	}
+}
+
+// Hand returns the hand the finger belongs to
+func (f Finger) Hand() Hand {
+	switch {
+	case f < Thumb:
+		return LeftHand
+	case f > Thumb:
+		return RightHand
+	default:
+		return EitherHand
+	}
+}
+
+func (h Hand) String() string {
+	switch h {
+	case LeftHand:
+		return "left"
+	case RightHand:
+		return "right"
+	default:
+		return "either"
+	}
+}
+
+// defaultFinger assigns fingers for custom layouts that do not list them,
+// following the standard row-staggered ANSI assignment. Keys beyond the
+// standard columns go to the pinky.
+func defaultFinger(row int, col int) Finger {
+	var columns []Finger
+	switch row {
+	case RowNumber:
+		columns = fingerRow(ansiFingers[0])
+	case RowTop:
+		columns = fingerRow(ansiFingers[1])
+	case RowHome:
+		columns = fingerRow(ansiFingers[2])
+	case RowBottom:
+		columns = fingerRow(ansiFingers[3])
+	default:
+		return Thumb
+	}
+	if col < len(columns) {
+		return columns[col]
+	}
+	return RightPinky
+}
+
+// fingerRow parses a finger row from a built-in definition
+func fingerRow(row string) []Finger {
+	fingers, err := parseFingers(row)
+	if err != nil {
+		panic(err) // built-in definitions are fixed
+	}
+	return fingers
+}
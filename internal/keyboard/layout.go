@@ -0,0 +1,241 @@
+package keyboard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rows of a keyboard, top to bottom
+const (
+	RowNumber = iota
+	RowTop
+	RowHome
+	RowBottom
+	RowSpace
+)
+
+// Key is a physical key and the characters it produces
+type Key struct {
+	Base    rune   // Character without shift
+	Shifted rune   // Character with shift (0 if none)
+	Row     int    // One of the Row constants
+	Col     int    // Position within the row, 0 = leftmost
+	Finger  Finger // Finger that presses the key in touch typing
+}
+
+// Label returns the key cap text, the shifted character for letters
+func (k Key) Label() string {
+	if k.Base == ' ' {
+		return "space"
+	}
+	if k.Shifted != 0 && strings.ToUpper(string(k.Base)) == string(k.Shifted) {
+		return string(k.Shifted)
+	}
+	return string(k.Base)
+}
+
+// keyRef locates a key in Layout.Rows
+type keyRef struct {
+	row     int
+	col     int
+	shifted bool
+}
+
+// Layout is a keyboard layout with key positions and finger assignments
+type Layout struct {
+	Name  string
+	Rows  [][]Key
+	index map[rune]keyRef
+}
+
+// Definition describes a layout the way built-in and custom YAML layouts are written
+type Definition struct {
+	Name    string   `yaml:"name"`
+	Rows    []string `yaml:"rows"`    // Unshifted characters per row, number row first
+	Shifted []string `yaml:"shifted"` // Optional shifted characters, same shape as rows
+	Fingers []string `yaml:"fingers"` // Optional finger digit per key, 0 = left pinky ... 8 = right pinky
+}
+
+// Find returns the key producing r and whether shift is needed
+func (l *Layout) Find(r rune) (Key, bool, bool) {
+	ref, ok := l.index[r]
+	if !ok {
+		return Key{}, false, false
+	}
+	return l.Rows[ref.row][ref.col], ref.shifted, true
+}
+
+// FingerFor returns the finger that types r
+func (l *Layout) FingerFor(r rune) (Finger, bool) {
+	key, _, ok := l.Find(r)
+	if !ok {
+		return 0, false
+	}
+	return key.Finger, true
+}
+
+// New builds a layout from a definition, validating its shape.
+// The space bar is added automatically as the last row.
+func New(def Definition) (*Layout, error) {
+	if def.Name == "" {
+		return nil, fmt.Errorf("layout has no name")
+	}
+	if len(def.Rows) == 0 || len(def.Rows) > RowSpace {
+		return nil, fmt.Errorf("layout %s: expected 1-%d rows, got %d", def.Name, RowSpace, len(def.Rows))
+	}
+	if len(def.Shifted) > 0 && len(def.Shifted) != len(def.Rows) {
+		return nil, fmt.Errorf("layout %s: shifted has %d rows, want %d", def.Name, len(def.Shifted), len(def.Rows))
+	}
+	if len(def.Fingers) > 0 && len(def.Fingers) != len(def.Rows) {
+		return nil, fmt.Errorf("layout %s: fingers has %d rows, want %d", def.Name, len(def.Fingers), len(def.Rows))
+	}
+
+	// Fewer than four rows means the number row was left out
+	firstRow := RowSpace - len(def.Rows)
+
+	layout := &Layout{Name: def.Name, index: make(map[rune]keyRef)}
+	for r, rowChars := range def.Rows {
+		row := firstRow + r
+		base := []rune(rowChars)
+
+		var shifted []rune
+		if len(def.Shifted) > 0 {
+			shifted = []rune(def.Shifted[r])
+			if len(shifted) != len(base) {
+				return nil, fmt.Errorf("layout %s: shifted row %d has %d keys, want %d", def.Name, r+1, len(shifted), len(base))
+			}
+		}
+
+		var fingers []Finger
+		if len(def.Fingers) > 0 {
+			parsed, err := parseFingers(def.Fingers[r])
+			if err != nil {
+				return nil, fmt.Errorf("layout %s: fingers row %d: %w", def.Name, r+1, err)
+			}
+			if len(parsed) != len(base) {
+				return nil, fmt.Errorf("layout %s: fingers row %d has %d entries, want %d", def.Name, r+1, len(parsed), len(base))
+			}
+			fingers = parsed
+		}
+
+		keys := make([]Key, len(base))
+		for c, char := range base {
+			key := Key{Base: char, Row: row, Col: c}
+			if shifted != nil {
+				key.Shifted = shifted[c]
+			} else if upper := []rune(strings.ToUpper(string(char))); len(upper) == 1 && upper[0] != char {
+				key.Shifted = upper[0]
+			}
+			if fingers != nil {
+				key.Finger = fingers[c]
+			} else {
+				key.Finger = defaultFinger(row, c)
+			}
+			keys[c] = key
+		}
+		layout.Rows = append(layout.Rows, keys)
+	}
+
+	layout.Rows = append(layout.Rows, []Key{{Base: ' ', Row: RowSpace, Col: 0, Finger: Thumb}})
+	layout.buildIndex()
+
+	return layout, nil
+}
+
+// buildIndex maps every character to its key; base characters win over shifted ones
+func (l *Layout) buildIndex() {
+	for r, row := range l.Rows {
+		for c, key := range row {
+			if _, exists := l.index[key.Base]; !exists {
+				l.index[key.Base] = keyRef{row: r, col: c}
+			}
+		}
+	}
+	for r, row := range l.Rows {
+		for c, key := range row {
+			if key.Shifted == 0 {
+				continue
+			}
+			if _, exists := l.index[key.Shifted]; !exists {
+				l.index[key.Shifted] = keyRef{row: r, col: c, shifted: true}
+			}
+		}
+	}
+}
+
+// Names returns the names of the built-in layouts
+func Names() []string {
+	names := make([]string, 0, len(builtinOrder))
+	names = append(names, builtinOrder...)
+	return names
+}
+
+// Load returns the named layout. Built-in layouts are matched first, then
+// <configDir>/layouts/<name>.yaml. An empty name selects QWERTY.
+func Load(name string, configDir string) (*Layout, error) {
+	key := normalizeName(name)
+	if key == "" {
+		key = "qwerty"
+	}
+
+	if def, ok := builtins[key]; ok {
+		return New(def)
+	}
+
+	if configDir == "" {
+		return nil, fmt.Errorf("unknown keyboard layout: %s", name)
+	}
+
+	path := filepath.Join(configDir, "layouts", name+".yaml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("unknown keyboard layout: %s (built-in: %s; custom layouts go in %s)",
+				name, strings.Join(Names(), ", "), filepath.Join(configDir, "layouts"))
+		}
+		return nil, fmt.Errorf("failed to read layout %s: %w", path, err)
+	}
+
+	return Parse(data)
+}
+
+// Parse builds a layout from its YAML definition
+func Parse(data []byte) (*Layout, error) {
+	var def Definition
+	if err := yaml.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("invalid layout file: %w", err)
+	}
+	return New(def)
+}
+
+// Default returns the QWERTY layout
+func Default() *Layout {
+	layout, err := New(builtins["qwerty"])
+	if err != nil {
+		panic(err) // built-in definitions are fixed
+	}
+	return layout
+}
+
+// normalizeName lower-cases a layout name and unifies separators, so "Colemak-DH" matches "colemak_dh"
+func normalizeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(name, "-", "_")
+	return strings.ReplaceAll(name, " ", "_")
+}
+
+// parseFingers reads a row of finger digits
+func parseFingers(row string) ([]Finger, error) {
+	fingers := make([]Finger, 0, len(row))
+	for _, c := range row {
+		if c < '0' || c > '8' {
+			return nil, fmt.Errorf("invalid finger %q (use 0-8)", c)
+		}
+		fingers = append(fingers, Finger(c-'0'))
+	}
+	return fingers, nil
+}
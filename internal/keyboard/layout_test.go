@@ -0,0 +1,190 @@
+package keyboard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuiltinLayouts_CoverAlphabet(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			layout, err := Load(name, "")
+			if err != nil {
+				t.Fatalf("Load(%q) error: %v", name, err)
+			}
+			for r := 'a'; r <= 'z'; r++ {
+				if _, _, ok := layout.Find(r); !ok {
+					t.Errorf("layout %s has no key for %q", name, r)
+				}
+				if _, shifted, ok := layout.Find(r - 'a' + 'A'); !ok || !shifted {
+					t.Errorf("layout %s: %q should be a shifted key", name, r-'a'+'A')
+				}
+			}
+			if key, _, ok := layout.Find(' '); !ok || key.Finger != Thumb {
+				t.Errorf("layout %s: space should be on the thumb", name)
+			}
+		})
+	}
+}
+
+func TestFingerFor(t *testing.T) {
+	tests := []struct {
+		layout string
+		char   rune
+		want   Finger
+	}{
+		{"qwerty", 'f', LeftIndex},
+		{"qwerty", 'j', RightIndex},
+		{"qwerty", 'a', LeftPinky},
+		{"qwerty", 'P', RightPinky},
+		{"qwertz", 'z', RightIndex},
+		{"qwertz", 'y', LeftPinky},
+		{"azerty", 'a', LeftPinky},
+		{"azerty", 'm', RightPinky},
+		{"dvorak", 'u', LeftIndex},
+		{"dvorak", 'h', RightIndex},
+		{"colemak", 't', LeftIndex},
+		{"colemak", 'n', RightIndex},
+		{"colemak_dh", 'g', LeftIndex},
+		{"colemak_dh", 'h', RightIndex},
+		{"workman", 't', LeftIndex},
+		{"workman", 'n', RightIndex},
+	}
+
+	for _, tt := range tests {
+		layout, err := Load(tt.layout, "")
+		if err != nil {
+			t.Fatalf("Load(%q) error: %v", tt.layout, err)
+		}
+		got, ok := layout.FingerFor(tt.char)
+		if !ok || got != tt.want {
+			t.Errorf("%s FingerFor(%q) = %v (%v), want %v", tt.layout, tt.char, got, ok, tt.want)
+		}
+	}
+}
+
+func TestFind_Position(t *testing.T) {
+	layout := Default()
+	key, shifted, ok := layout.Find('F')
+	if !ok {
+		t.Fatal("Find('F') not found")
+	}
+	if !shifted {
+		t.Error("'F' should need shift")
+	}
+	if key.Row != RowHome || key.Col != 3 {
+		t.Errorf("'F' at row %d col %d, want row %d col 3", key.Row, key.Col, RowHome)
+	}
+	if key.Label() != "F" {
+		t.Errorf("Label() = %q, want %q", key.Label(), "F")
+	}
+}
+
+func TestLoad_NameNormalization(t *testing.T) {
+	for _, name := range []string{"Colemak-DH", "colemak dh", "COLEMAK_DH"} {
+		layout, err := Load(name, "")
+		if err != nil {
+			t.Errorf("Load(%q) error: %v", name, err)
+			continue
+		}
+		if layout.Name != "Colemak-DH" {
+			t.Errorf("Load(%q) = %s, want Colemak-DH", name, layout.Name)
+		}
+	}
+
+	layout, err := Load("", "")
+	if err != nil || layout.Name != "QWERTY" {
+		t.Errorf("Load(\"\") = %v, %v; want QWERTY", layout, err)
+	}
+}
+
+func TestLoad_CustomLayout(t *testing.T) {
+	configDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(configDir, "layouts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	custom := `name: Mine
+rows:
+  - "qwfpg"
+  - "arstd"
+  - "zxcvb"
+fingers:
+  - "01233"
+  - "01233"
+  - "01233"
+`
+	if err := os.WriteFile(filepath.Join(configDir, "layouts", "mine.yaml"), []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	layout, err := Load("mine", configDir)
+	if err != nil {
+		t.Fatalf("Load(mine) error: %v", err)
+	}
+	if layout.Name != "Mine" {
+		t.Errorf("Name = %q, want Mine", layout.Name)
+	}
+
+	// Three rows are read as top, home and bottom
+	key, shifted, ok := layout.Find('R')
+	if !ok || !shifted || key.Row != RowHome || key.Finger != LeftRing {
+		t.Errorf("Find('R') = %+v shifted=%v ok=%v, want home row left ring", key, shifted, ok)
+	}
+}
+
+func TestLoad_Unknown(t *testing.T) {
+	if _, err := Load("nonexistent", t.TempDir()); err == nil {
+		t.Error("Load() of an unknown layout should fail")
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		def  Definition
+	}{
+		{"no name", Definition{Rows: []string{"abc"}}},
+		{"no rows", Definition{Name: "x"}},
+		{"shifted shape mismatch", Definition{Name: "x", Rows: []string{"abc"}, Shifted: []string{"AB"}}},
+		{"finger count mismatch", Definition{Name: "x", Rows: []string{"abc"}, Fingers: []string{"01"}}},
+		{"invalid finger", Definition{Name: "x", Rows: []string{"abc"}, Fingers: []string{"01x"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.def); err == nil {
+				t.Error("New() should reject the definition")
+			}
+		})
+	}
+}
+
+func TestDefaultFinger(t *testing.T) {
+	layout, err := New(Definition{Name: "x", Rows: []string{"1234567890-=+", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if finger, _ := layout.FingerFor('f'); finger != LeftIndex {
+		t.Errorf("default finger for 'f' = %v, want left index", finger)
+	}
+	if finger, _ := layout.FingerFor('+'); finger != RightPinky {
+		t.Errorf("default finger for extra key = %v, want right pinky", finger)
+	}
+}
+
+func TestFinger_Hand(t *testing.T) {
+	if LeftIndex.Hand() != LeftHand {
+		t.Error("left index should be on the left hand")
+	}
+	if RightPinky.Hand() != RightHand {
+		t.Error("right pinky should be on the right hand")
+	}
+	if Thumb.Hand() != EitherHand {
+		t.Error("thumb should be on either hand")
+	}
+	if RightRing.String() != "right ring" {
+		t.Errorf("String() = %q, want %q", RightRing.String(), "right ring")
+	}
+}
@@ -0,0 +1,191 @@
+package metrics
+
+import (
+	"go-touch/internal/keyboard"
+	"go-touch/internal/types"
+	"sort"
+)
+
+// ErrorClass groups a mistyped character by how the wrong key relates to the right one
+type ErrorClass int
+
+const (
+	SameFinger   ErrorClass = iota // Right finger, wrong key: a reach problem
+	WrongFinger                    // Wrong finger on the right hand
+	WrongHand                      // Key on the other hand: a coordination problem
+	Unclassified                   // Space, thumb keys or characters off the layout
+)
+
+func (c ErrorClass) String() string {
+	switch c {
+	case SameFinger:
+		return "same finger"
+	case WrongFinger:
+		return "wrong finger"
+	case WrongHand:
+		return "wrong hand"
+	default:
+		return "other"
+	}
+}
+
+// Confusion is one expected -> typed substitution and how often it happened
+type Confusion struct {
+	Expected rune
+	Typed    rune
+	Count    int
+}
+
+// Confusions builds the confusion matrix of a keystroke log: for every
+// expected character, what was typed instead and how often
+func Confusions(keystrokes []Keystroke) map[string]map[string]int {
+	matrix := make(map[string]map[string]int)
+	for _, k := range keystrokes {
+		if k.Backspace || k.Expected == 0 || k.Correct() {
+			continue
+		}
+		expected := string(k.Expected)
+		if matrix[expected] == nil {
+			matrix[expected] = make(map[string]int)
+		}
+		matrix[expected][string(k.Typed)]++
+	}
+	return matrix
+}
+
+// TopConfusions returns the n most frequent substitutions, most frequent first
+func TopConfusions(matrix map[string]map[string]int, n int) []Confusion {
+	var confusions []Confusion
+	for expected, row := range matrix {
+		for typed, count := range row {
+			e, t := []rune(expected), []rune(typed)
+			if len(e) != 1 || len(t) != 1 {
+				continue
+			}
+			confusions = append(confusions, Confusion{Expected: e[0], Typed: t[0], Count: count})
+		}
+	}
+	sort.Slice(confusions, func(i, j int) bool {
+		a, b := confusions[i], confusions[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Expected != b.Expected {
+			return a.Expected < b.Expected
+		}
+		return a.Typed < b.Typed
+	})
+	if len(confusions) > n {
+		confusions = confusions[:n]
+	}
+	return confusions
+}
+
+// Classify returns the error class of typing typed where expected was wanted
+func Classify(layout *keyboard.Layout, expected, typed rune) ErrorClass {
+	want, _, ok := layout.Find(expected)
+	if !ok {
+		return Unclassified
+	}
+	got, _, ok := layout.Find(typed)
+	if !ok {
+		return Unclassified
+	}
+	wantHand, gotHand := want.Finger.Hand(), got.Finger.Hand()
+	switch {
+	case wantHand == keyboard.EitherHand || gotHand == keyboard.EitherHand:
+		return Unclassified
+	case want.Finger == got.Finger:
+		return SameFinger
+	case wantHand == gotHand:
+		return WrongFinger
+	default:
+		return WrongHand
+	}
+}
+
+// FingerAnalysis breaks a session's accuracy and speed down by finger and hand
+type FingerAnalysis struct {
+	Fingers map[keyboard.Finger]types.KeyStat
+	Hands   map[keyboard.Hand]types.KeyStat
+	Classes map[ErrorClass]int // Substitution errors by class
+}
+
+// AnalyzeFingers attributes per-character stats to the fingers that type them
+// on the given layout and classifies the substitutions in the confusion matrix
+func AnalyzeFingers(layout *keyboard.Layout, keyStats map[string]types.KeyStat, confusions map[string]map[string]int) FingerAnalysis {
+	analysis := FingerAnalysis{
+		Fingers: make(map[keyboard.Finger]types.KeyStat),
+		Hands:   make(map[keyboard.Hand]types.KeyStat),
+		Classes: make(map[ErrorClass]int),
+	}
+
+	for char, stat := range keyStats {
+		runes := []rune(char)
+		if len(runes) != 1 {
+			continue
+		}
+		finger, ok := layout.FingerFor(runes[0])
+		if !ok {
+			continue
+		}
+		analysis.Fingers[finger] = analysis.Fingers[finger].Add(stat)
+		analysis.Hands[finger.Hand()] = analysis.Hands[finger.Hand()].Add(stat)
+	}
+
+	for expected, row := range confusions {
+		for typed, count := range row {
+			e, t := []rune(expected), []rune(typed)
+			if len(e) != 1 || len(t) != 1 {
+				continue
+			}
+			analysis.Classes[Classify(layout, e[0], t[0])] += count
+		}
+	}
+
+	return analysis
+}
+
+// WeakestFinger returns the finger with the highest error rate, ignoring
+// fingers with fewer than minPresses presses
+func (a FingerAnalysis) WeakestFinger(minPresses int) (keyboard.Finger, bool) {
+	return a.worstFinger(minPresses, func(s types.KeyStat) float64 { return s.ErrorRate() })
+}
+
+// SlowestFinger returns the finger with the highest mean latency, ignoring
+// fingers with fewer than minPresses presses
+func (a FingerAnalysis) SlowestFinger(minPresses int) (keyboard.Finger, bool) {
+	return a.worstFinger(minPresses, func(s types.KeyStat) float64 { return float64(s.MeanLatency()) })
+}
+
+func (a FingerAnalysis) worstFinger(minPresses int, value func(types.KeyStat) float64) (keyboard.Finger, bool) {
+	var worst keyboard.Finger
+	found := false
+	for _, finger := range keyboard.Fingers {
+		stat, ok := a.Fingers[finger]
+		if !ok || stat.Presses < minPresses || value(stat) <= 0 {
+			continue
+		}
+		if !found || value(stat) > value(a.Fingers[worst]) {
+			worst, found = finger, true
+		}
+	}
+	return worst, found
+}
+
+// FingersByErrorRate returns up to n fingers that made errors, highest error rate first
+func (a FingerAnalysis) FingersByErrorRate(n int) []keyboard.Finger {
+	var fingers []keyboard.Finger
+	for _, finger := range keyboard.Fingers {
+		if a.Fingers[finger].Errors > 0 {
+			fingers = append(fingers, finger)
+		}
+	}
+	sort.SliceStable(fingers, func(i, j int) bool {
+		return a.Fingers[fingers[i]].ErrorRate() > a.Fingers[fingers[j]].ErrorRate()
+	})
+	if len(fingers) > n {
+		fingers = fingers[:n]
+	}
+	return fingers
+}
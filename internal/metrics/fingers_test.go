@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"go-touch/internal/keyboard"
+	"go-touch/internal/types"
+	"testing"
+	"time"
+)
+
+func TestConfusions(t *testing.T) {
+	keystrokes := typeText("tesr wprd", "test word", 100*time.Millisecond)
+	keystrokes = append(keystrokes, Keystroke{Pos: 8, Backspace: true})
+
+	matrix := Confusions(keystrokes)
+
+	if got := matrix["t"]["r"]; got != 1 {
+		t.Errorf("matrix[t][r] = %d, want 1", got)
+	}
+	if got := matrix["o"]["p"]; got != 1 {
+		t.Errorf("matrix[o][p] = %d, want 1", got)
+	}
+	if len(matrix) != 2 {
+		t.Errorf("len(matrix) = %d, want 2 (correct keys and backspaces are not confusions)", len(matrix))
+	}
+}
+
+func TestTopConfusions(t *testing.T) {
+	matrix := map[string]map[string]int{"e": {"r": 3, "w": 1}, "i": {"o": 1}}
+
+	top := TopConfusions(matrix, 2)
+	if len(top) != 2 {
+		t.Fatalf("len(TopConfusions()) = %d, want 2", len(top))
+	}
+	if top[0] != (Confusion{Expected: 'e', Typed: 'r', Count: 3}) {
+		t.Errorf("TopConfusions()[0] = %+v, want e->r x3", top[0])
+	}
+	if top[1] != (Confusion{Expected: 'e', Typed: 'w', Count: 1}) {
+		t.Errorf("TopConfusions()[1] = %+v, want e->w x1 (ties ordered by character)", top[1])
+	}
+}
+
+func TestClassify(t *testing.T) {
+	layout := keyboard.Default()
+
+	tests := []struct {
+		expected rune
+		typed    rune
+		want     ErrorClass
+	}{
+		{'r', 'f', SameFinger},   // Left index reaching to the wrong row
+		{'e', 'r', WrongFinger},  // Left middle key typed with the left index
+		{'e', 'i', WrongHand},    // Mirror key on the right hand
+		{'a', 'A', SameFinger},   // Shift slip on the right key
+		{'a', ' ', Unclassified}, // Space bar
+		{'a', 'é', Unclassified}, // Not on the layout
+	}
+
+	for _, tt := range tests {
+		if got := Classify(layout, tt.expected, tt.typed); got != tt.want {
+			t.Errorf("Classify(%q, %q) = %v, want %v", tt.expected, tt.typed, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeFingers(t *testing.T) {
+	layout := keyboard.Default()
+	keyStats := map[string]types.KeyStat{
+		"a": {Presses: 10, Errors: 1, Latency: 2 * time.Second},
+		"q": {Presses: 10, Errors: 4, Latency: 3 * time.Second},
+		"j": {Presses: 20, Errors: 0, Latency: 2 * time.Second},
+	}
+	confusions := map[string]map[string]int{
+		"q": {"a": 3, "p": 1},
+		"a": {"s": 1},
+	}
+
+	analysis := AnalyzeFingers(layout, keyStats, confusions)
+
+	pinky := analysis.Fingers[keyboard.LeftPinky]
+	if pinky.Presses != 20 || pinky.Errors != 5 {
+		t.Errorf("left pinky = %+v, want 20 presses and 5 errors", pinky)
+	}
+	if right := analysis.Hands[keyboard.RightHand]; right.Presses != 20 || right.Errors != 0 {
+		t.Errorf("right hand = %+v, want 20 presses and 0 errors", right)
+	}
+
+	wantClasses := map[ErrorClass]int{SameFinger: 3, WrongFinger: 1, WrongHand: 1}
+	for class, want := range wantClasses {
+		if got := analysis.Classes[class]; got != want {
+			t.Errorf("Classes[%v] = %d, want %d", class, got, want)
+		}
+	}
+
+	if finger, ok := analysis.WeakestFinger(5); !ok || finger != keyboard.LeftPinky {
+		t.Errorf("WeakestFinger() = %v, %v, want left pinky", finger, ok)
+	}
+	if finger, ok := analysis.SlowestFinger(5); !ok || finger != keyboard.LeftPinky {
+		t.Errorf("SlowestFinger() = %v, %v, want left pinky", finger, ok)
+	}
+	if fingers := analysis.FingersByErrorRate(3); len(fingers) != 1 || fingers[0] != keyboard.LeftPinky {
+		t.Errorf("FingersByErrorRate() = %v, want [left pinky]", fingers)
+	}
+}
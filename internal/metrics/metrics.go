@@ -0,0 +1,169 @@
+package metrics
+
+import (
+	"go-touch/internal/types"
+	"math"
+	"strings"
+	"time"
+)
+
+// charsPerWord is the standard word length used for all WPM figures
+const charsPerWord = 5.0
+
+// SkippedChar fills the typed text for target characters passed over without
+// typing them; it never matches the target and is not counted as typed
+const SkippedChar = "\x00"
+
+// TypedChars returns the number of characters actually typed in typed
+func TypedChars(typed string) int {
+	return len(typed) - strings.Count(typed, SkippedChar)
+}
+
+// Keystroke is a single key press recorded during a session
+type Keystroke struct {
+	At        time.Duration // Time since the session started
+	Pos       int           // Index in the target text the key applied to
+	Expected  rune          // Character expected at Pos (0 past the end of the text)
+	Typed     rune          // Character that was typed (0 for backspace)
+	Backspace bool          // True if the key deleted the previous character
+}
+
+// Correct reports whether the keystroke typed the expected character
+func (k Keystroke) Correct() bool {
+	return !k.Backspace && k.Typed == k.Expected
+}
+
+// Result holds the final metrics of a session
+type Result struct {
+	GrossWPM          float32 // Typed characters in the final text per minute, in words
+	NetWPM            float32 // Gross WPM minus uncorrected errors per minute
+	RawWPM            float32 // Every character keystroke per minute, in words, including deleted ones
+	Accuracy          float32 // Percentage of character keystrokes that were correct
+	Errors            int     // Incorrect character keystrokes
+	CorrectedErrors   int     // Errors that were later fixed with backspace
+	UncorrectedErrors int     // Errors left in the final text
+	Consistency       float32 // 0-100, higher means a steadier per-second speed
+}
+
+// Compute derives the session metrics from the keystroke log, the final typed
+// text, the target text and the active typing time
+func Compute(keystrokes []Keystroke, typed, target string, elapsed time.Duration) Result {
+	var result Result
+
+	charKeys := 0
+	for _, k := range keystrokes {
+		if k.Backspace {
+			continue
+		}
+		charKeys++
+		if !k.Correct() {
+			result.Errors++
+		}
+	}
+
+	result.UncorrectedErrors = countUncorrected(typed, target)
+	result.CorrectedErrors = result.Errors - result.UncorrectedErrors
+	if result.CorrectedErrors < 0 {
+		result.CorrectedErrors = 0
+	}
+
+	if charKeys > 0 {
+		result.Accuracy = float32(charKeys-result.Errors) / float32(charKeys) * 100
+	}
+
+	minutes := elapsed.Minutes()
+	if minutes <= 0 {
+		return result
+	}
+
+	gross := float64(TypedChars(typed)) / charsPerWord / minutes
+	net := gross - float64(result.UncorrectedErrors)/minutes
+	if net < 0 {
+		net = 0
+	}
+	result.GrossWPM = float32(gross)
+	result.NetWPM = float32(net)
+	result.RawWPM = float32(float64(charKeys) / charsPerWord / minutes)
+	result.Consistency = consistency(keystrokes, elapsed)
+
+	return result
+}
+
+// countUncorrected counts positions in the typed text that differ from the target
+func countUncorrected(typed, target string) int {
+	count := 0
+	for i := 0; i < len(typed); i++ {
+		if i >= len(target) || typed[i] != target[i] {
+			count++
+		}
+	}
+	return count
+}
+
+// consistency scores how steady the per-second typing speed was, as
+// 100 * (1 - coefficient of variation), clamped to 0-100.
+// Sessions shorter than two seconds have no meaningful score and return 0.
+func consistency(keystrokes []Keystroke, elapsed time.Duration) float32 {
+	seconds := int(elapsed / time.Second)
+	if seconds < 2 {
+		return 0
+	}
+
+	// Characters per whole second; the trailing partial second is ignored
+	buckets := make([]float64, seconds)
+	for _, k := range keystrokes {
+		if k.Backspace {
+			continue
+		}
+		index := int(k.At / time.Second)
+		if index >= 0 && index < seconds {
+			buckets[index]++
+		}
+	}
+
+	mean, stdDev := meanStdDev(buckets)
+	if mean == 0 {
+		return 0
+	}
+
+	score := 100 * (1 - stdDev/mean)
+	return float32(math.Max(0, math.Min(100, score)))
+}
+
+// meanStdDev returns the mean and population standard deviation of values
+func meanStdDev(values []float64) (mean, stdDev float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	for _, v := range values {
+		stdDev += (v - mean) * (v - mean)
+	}
+	stdDev = math.Sqrt(stdDev / float64(len(values)))
+	return mean, stdDev
+}
+
+// KeyStats aggregates presses, errors and latency per expected character.
+// Latency is measured from the previous keystroke, so the first key of the
+// session has none and only counts towards presses and errors.
+func KeyStats(keystrokes []Keystroke) map[string]types.KeyStat {
+	stats := make(map[string]types.KeyStat)
+	for i, k := range keystrokes {
+		if k.Backspace || k.Expected == 0 {
+			continue
+		}
+		stat := types.KeyStat{Presses: 1}
+		if !k.Correct() {
+			stat.Errors = 1
+		}
+		if i > 0 {
+			stat.Latency = k.At - keystrokes[i-1].At
+		}
+		key := string(k.Expected)
+		stats[key] = stats[key].Add(stat)
+	}
+	return stats
+}
@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// typeText builds a keystroke log for typing each character of typed, one per interval
+func typeText(typed, target string, interval time.Duration) []Keystroke {
+	keystrokes := make([]Keystroke, 0, len(typed))
+	for i := 0; i < len(typed); i++ {
+		k := Keystroke{
+			At:    time.Duration(i+1) * interval,
+			Pos:   i,
+			Typed: rune(typed[i]),
+		}
+		if i < len(target) {
+			k.Expected = rune(target[i])
+		}
+		keystrokes = append(keystrokes, k)
+	}
+	return keystrokes
+}
+
+func approxEqual(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 0.01
+}
+
+func TestCompute_PerfectRun(t *testing.T) {
+	text := "hello world"
+	keystrokes := typeText(text, text, 100*time.Millisecond)
+
+	result := Compute(keystrokes, text, text+" and more untyped text", time.Minute)
+
+	// 11 characters = 2.2 words over one minute; untyped target text must not count
+	if !approxEqual(result.GrossWPM, 2.2) {
+		t.Errorf("GrossWPM = %v, want 2.2", result.GrossWPM)
+	}
+	if !approxEqual(result.NetWPM, 2.2) {
+		t.Errorf("NetWPM = %v, want 2.2", result.NetWPM)
+	}
+	if !approxEqual(result.RawWPM, 2.2) {
+		t.Errorf("RawWPM = %v, want 2.2", result.RawWPM)
+	}
+	if result.Accuracy != 100 {
+		t.Errorf("Accuracy = %v, want 100", result.Accuracy)
+	}
+	if result.Errors != 0 || result.CorrectedErrors != 0 || result.UncorrectedErrors != 0 {
+		t.Errorf("Errors = %d/%d/%d, want 0/0/0", result.Errors, result.CorrectedErrors, result.UncorrectedErrors)
+	}
+}
+
+func TestCompute_CorrectedAndUncorrectedErrors(t *testing.T) {
+	target := "abcde"
+	keystrokes := []Keystroke{
+		{At: 1 * time.Second, Pos: 0, Expected: 'a', Typed: 'a'},
+		{At: 2 * time.Second, Pos: 1, Expected: 'b', Typed: 'x'}, // corrected below
+		{At: 3 * time.Second, Pos: 1, Backspace: true},
+		{At: 4 * time.Second, Pos: 1, Expected: 'b', Typed: 'b'},
+		{At: 5 * time.Second, Pos: 2, Expected: 'c', Typed: 'y'}, // left in place
+		{At: 6 * time.Second, Pos: 3, Expected: 'd', Typed: 'd'},
+	}
+
+	result := Compute(keystrokes, "abyd", target, 30*time.Second)
+
+	if result.Errors != 2 {
+		t.Errorf("Errors = %d, want 2", result.Errors)
+	}
+	if result.CorrectedErrors != 1 {
+		t.Errorf("CorrectedErrors = %d, want 1", result.CorrectedErrors)
+	}
+	if result.UncorrectedErrors != 1 {
+		t.Errorf("UncorrectedErrors = %d, want 1", result.UncorrectedErrors)
+	}
+
+	// 4 final chars over half a minute = 1.6 gross; minus 1 error / 0.5 min = 0
+	if !approxEqual(result.GrossWPM, 1.6) {
+		t.Errorf("GrossWPM = %v, want 1.6", result.GrossWPM)
+	}
+	if result.NetWPM != 0 {
+		t.Errorf("NetWPM = %v, want 0 (clamped)", result.NetWPM)
+	}
+	// 5 character keystrokes including the deleted one = 2.0 raw
+	if !approxEqual(result.RawWPM, 2.0) {
+		t.Errorf("RawWPM = %v, want 2.0", result.RawWPM)
+	}
+	if !approxEqual(result.Accuracy, 60) {
+		t.Errorf("Accuracy = %v, want 60", result.Accuracy)
+	}
+}
+
+func TestCompute_ZeroDuration(t *testing.T) {
+	result := Compute(nil, "", "text", 0)
+
+	if result.GrossWPM != 0 || result.NetWPM != 0 || result.RawWPM != 0 {
+		t.Errorf("WPM should be zero for zero duration, got %+v", result)
+	}
+	if math.IsNaN(float64(result.Accuracy)) {
+		t.Error("Accuracy should not be NaN")
+	}
+}
+
+func TestConsistency(t *testing.T) {
+	// Perfectly even: 5 keys in each of 4 seconds
+	even := typeText("aaaaaaaaaaaaaaaaaaaa", "aaaaaaaaaaaaaaaaaaaa", 200*time.Millisecond)
+	for i := range even {
+		even[i].At -= time.Millisecond
+	}
+	if got := consistency(even, 4*time.Second); !approxEqual(got, 100) {
+		t.Errorf("consistency(even) = %v, want 100", got)
+	}
+
+	// Bursty: all keys in the first second, nothing after
+	bursty := typeText("aaaaaaaaaa", "aaaaaaaaaa", 50*time.Millisecond)
+	if got := consistency(bursty, 4*time.Second); got >= 50 {
+		t.Errorf("consistency(bursty) = %v, want well below 50", got)
+	}
+
+	if got := consistency(even, time.Second); got != 0 {
+		t.Errorf("consistency() under two seconds = %v, want 0", got)
+	}
+}
+
+func TestKeystroke_Correct(t *testing.T) {
+	if !(Keystroke{Expected: 'a', Typed: 'a'}).Correct() {
+		t.Error("matching keystroke should be correct")
+	}
+	if (Keystroke{Expected: 'a', Typed: 'b'}).Correct() {
+		t.Error("mismatched keystroke should not be correct")
+	}
+	if (Keystroke{Backspace: true}).Correct() {
+		t.Error("backspace should not count as correct")
+	}
+}
+
+func TestKeyStats(t *testing.T) {
+	// "abca" typed as "abxa" at 100ms intervals, then a backspace
+	keystrokes := typeText("abxa", "abca", 100*time.Millisecond)
+	keystrokes = append(keystrokes, Keystroke{At: 500 * time.Millisecond, Pos: 3, Backspace: true})
+
+	stats := KeyStats(keystrokes)
+
+	a := stats["a"]
+	if a.Presses != 2 || a.Errors != 0 {
+		t.Errorf("stats[a] = %+v, want 2 presses and 0 errors", a)
+	}
+	// The first keystroke has no latency, the second "a" follows 100ms after "x"
+	if a.Latency != 100*time.Millisecond {
+		t.Errorf("stats[a].Latency = %v, want 100ms", a.Latency)
+	}
+	if c := stats["c"]; c.Presses != 1 || c.Errors != 1 {
+		t.Errorf("stats[c] = %+v, want 1 press and 1 error", c)
+	}
+	if _, ok := stats["x"]; ok {
+		t.Error("KeyStats should be keyed by the expected character, not the typed one")
+	}
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"go-touch/internal/types"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// NGrams measures the transition time of every n-character sequence typed
+// correctly in one go: the time from the first to the last of its keystrokes.
+// A mistake or backspace breaks the run, as do n-grams spanning whitespace.
+// N-grams are lowercased so "Th" and "th" count together.
+func NGrams(keystrokes []Keystroke, n int) map[string]types.NGramStat {
+	stats := make(map[string]types.NGramStat)
+	if n < 2 {
+		return stats
+	}
+
+	run := make([]Keystroke, 0, n)
+	for _, k := range keystrokes {
+		if !k.Correct() || unicode.IsSpace(k.Expected) {
+			run = run[:0]
+			continue
+		}
+		if len(run) > 0 && k.Pos != run[len(run)-1].Pos+1 {
+			run = run[:0]
+		}
+		run = append(run, k)
+		if len(run) > n {
+			run = run[1:]
+		}
+		if len(run) < n {
+			continue
+		}
+
+		var ngram strings.Builder
+		for _, r := range run {
+			ngram.WriteRune(unicode.ToLower(r.Expected))
+		}
+		key := ngram.String()
+		stats[key] = stats[key].Add(types.NGramStat{Count: 1, Total: run[n-1].At - run[0].At})
+	}
+	return stats
+}
+
+// SlowNGrams returns up to limit n-grams with the highest mean time per
+// transition and at least minCount samples, each with up to three sample words from text
+func SlowNGrams(stats map[string]types.NGramStat, text string, limit int, minCount int) []types.SlowNGram {
+	var slow []types.SlowNGram
+	for ngram, stat := range stats {
+		if stat.Count < minCount {
+			continue
+		}
+		slow = append(slow, types.SlowNGram{NGram: ngram, Mean: stat.Mean(), Count: stat.Count})
+	}
+	sort.Slice(slow, func(i, j int) bool {
+		a, b := types.PerTransition(slow[i].NGram, slow[i].Mean), types.PerTransition(slow[j].NGram, slow[j].Mean)
+		if a != b {
+			return a > b
+		}
+		return slow[i].NGram < slow[j].NGram
+	})
+	if len(slow) > limit {
+		slow = slow[:limit]
+	}
+	for i := range slow {
+		slow[i].Words = WordsContaining(text, slow[i].NGram, 3)
+	}
+	return slow
+}
+
+// WordsContaining returns up to limit distinct words of text containing ngram, ignoring case and punctuation
+func WordsContaining(text, ngram string, limit int) []string {
+	var words []string
+	seen := make(map[string]bool)
+	for _, field := range strings.Fields(text) {
+		word := strings.ToLower(strings.TrimFunc(field, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }))
+		if word == "" || seen[word] || !strings.Contains(word, ngram) {
+			continue
+		}
+		seen[word] = true
+		words = append(words, word)
+		if len(words) == limit {
+			break
+		}
+	}
+	return words
+}
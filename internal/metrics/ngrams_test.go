@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"go-touch/internal/types"
+	"testing"
+	"time"
+)
+
+func TestNGrams(t *testing.T) {
+	// "the then" with "x" typed for the second "e" and then corrected
+	keystrokes := typeText("the th", "the then", 100*time.Millisecond)
+	keystrokes = append(keystrokes,
+		Keystroke{At: 700 * time.Millisecond, Pos: 6, Expected: 'e', Typed: 'x'},
+		Keystroke{At: 800 * time.Millisecond, Pos: 6, Backspace: true},
+		Keystroke{At: 900 * time.Millisecond, Pos: 6, Expected: 'e', Typed: 'e'},
+		Keystroke{At: 1000 * time.Millisecond, Pos: 7, Expected: 'n', Typed: 'n'},
+	)
+
+	bigrams := NGrams(keystrokes, 2)
+	if got := bigrams["th"]; got.Count != 2 || got.Total != 200*time.Millisecond {
+		t.Errorf("bigrams[th] = %+v, want 2 samples totalling 200ms", got)
+	}
+	// The mistake breaks the run, so "he" only counts from the first word
+	if got := bigrams["he"]; got.Count != 1 {
+		t.Errorf("bigrams[he] = %+v, want 1 sample", got)
+	}
+	if got := bigrams["en"]; got.Count != 1 || got.Total != 100*time.Millisecond {
+		t.Errorf("bigrams[en] = %+v, want 1 sample of 100ms", got)
+	}
+	if _, ok := bigrams["e "]; ok {
+		t.Error("NGrams should skip n-grams containing whitespace")
+	}
+
+	trigrams := NGrams(keystrokes, 3)
+	if got := trigrams["the"]; got.Count != 1 || got.Total != 200*time.Millisecond {
+		t.Errorf("trigrams[the] = %+v, want 1 sample of 200ms", got)
+	}
+	if len(trigrams) != 1 {
+		t.Errorf("len(trigrams) = %d, want 1", len(trigrams))
+	}
+}
+
+func TestSlowNGrams_RanksPerTransition(t *testing.T) {
+	// The trigram takes longer in total but is quicker per transition
+	stats := map[string]types.NGramStat{
+		"the": {Count: 2, Total: 600 * time.Millisecond},
+		"qu":  {Count: 2, Total: 400 * time.Millisecond},
+	}
+	slow := SlowNGrams(stats, "", 5, 2)
+	if len(slow) != 2 || slow[0].NGram != "qu" || slow[1].NGram != "the" {
+		t.Errorf("SlowNGrams() = %+v, want qu before the", slow)
+	}
+}
+
+func TestSlowNGrams(t *testing.T) {
+	stats := map[string]types.NGramStat{
+		"th": {Count: 2, Total: 400 * time.Millisecond},
+		"qu": {Count: 2, Total: 800 * time.Millisecond},
+		"zz": {Count: 1, Total: time.Second},
+	}
+
+	slow := SlowNGrams(stats, "The quick quiet queen, then THE end.", 5, 2)
+
+	if len(slow) != 2 {
+		t.Fatalf("len(SlowNGrams()) = %d, want 2 (rare n-grams are skipped)", len(slow))
+	}
+	if slow[0].NGram != "qu" || slow[0].Mean != 400*time.Millisecond {
+		t.Errorf("SlowNGrams()[0] = %+v, want qu at 400ms", slow[0])
+	}
+	wantWords := []string{"quick", "quiet", "queen"}
+	if len(slow[0].Words) != 3 || slow[0].Words[0] != wantWords[0] || slow[0].Words[2] != wantWords[2] {
+		t.Errorf("SlowNGrams()[0].Words = %v, want %v", slow[0].Words, wantWords)
+	}
+	if len(slow[1].Words) != 2 {
+		t.Errorf("SlowNGrams()[1].Words = %v, want [the then] without duplicates", slow[1].Words)
+	}
+}
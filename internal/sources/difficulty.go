@@ -0,0 +1,79 @@
+package sources
+
+import "fmt"
+
+// Vocabulary rarity levels
+const (
+	VocabularyCommon = iota // Everyday words
+	VocabularyMixed         // Some less common words
+	VocabularyRare          // Rich, uncommon vocabulary
+)
+
+// Punctuation density levels
+const (
+	PunctuationNone  = iota // Only a final full stop
+	PunctuationLight        // Ordinary sentence punctuation
+	PunctuationHeavy        // Plenty of commas, quotes, semicolons and the like
+)
+
+// Difficulty describes how demanding generated text should be
+type Difficulty struct {
+	MinLength   int  // Shortest sentence in characters
+	MaxLength   int  // Longest sentence in characters
+	Vocabulary  int  // One of the Vocabulary constants
+	Punctuation int  // One of the Punctuation constants
+	Numbers     bool // Include numbers and symbols
+}
+
+// DifficultyLevels lists the difficulty steps from easiest to hardest
+var DifficultyLevels = []Difficulty{
+	{MinLength: 30, MaxLength: 50, Vocabulary: VocabularyCommon, Punctuation: PunctuationNone},
+	{MinLength: 40, MaxLength: 65, Vocabulary: VocabularyCommon, Punctuation: PunctuationLight},
+	{MinLength: 50, MaxLength: 80, Vocabulary: VocabularyMixed, Punctuation: PunctuationLight},
+	{MinLength: 60, MaxLength: 95, Vocabulary: VocabularyMixed, Punctuation: PunctuationHeavy},
+	{MinLength: 70, MaxLength: 110, Vocabulary: VocabularyRare, Punctuation: PunctuationHeavy, Numbers: true},
+}
+
+// DefaultDifficultyLevel matches the sentences requested before difficulty was adjustable
+const DefaultDifficultyLevel = 2
+
+// isZero reports whether no difficulty was set
+func (d Difficulty) isZero() bool {
+	return d == Difficulty{}
+}
+
+// length returns the sentence length range, the default when no difficulty was set
+func (d Difficulty) length() (int, int) {
+	if d.isZero() {
+		d = DifficultyLevels[DefaultDifficultyLevel]
+	}
+	return d.MinLength, d.MaxLength
+}
+
+// describe renders the style levers as prompt lines
+func (d Difficulty) describe() string {
+	if d.isZero() {
+		return ""
+	}
+
+	var lines string
+	switch d.Vocabulary {
+	case VocabularyCommon:
+		lines += "Use only common, everyday words.\n"
+	case VocabularyRare:
+		lines += "Use rich vocabulary with some uncommon words.\n"
+	}
+	switch d.Punctuation {
+	case PunctuationNone:
+		lines += "Avoid punctuation except the final full stop.\n"
+	case PunctuationHeavy:
+		lines += "Use plenty of punctuation: commas, quotes, semicolons, colons and parentheses.\n"
+	}
+	if d.Numbers {
+		lines += "Include at least one number and a symbol such as %, $, # or &.\n"
+	}
+	if lines == "" {
+		return ""
+	}
+	return fmt.Sprintf("Style:\n%s", lines)
+}
@@ -0,0 +1,91 @@
+package sources
+
+import (
+	"fmt"
+	"go-touch/internal/metrics"
+	"strings"
+)
+
+// AdaptiveSource generates follow-up text aimed at the user's weaknesses
+type AdaptiveSource interface {
+	GetAdaptiveSentence(previousSentence string, hints PracticeHints) (string, error)
+}
+
+// PracticeHints describes what the user struggled with so far
+type PracticeHints struct {
+	ErrorChars  []rune              // Target characters that were mistyped
+	ErrorWords  []string            // Words that contained mistakes
+	Confusions  []metrics.Confusion // Most frequent expected -> typed substitutions
+	WeakFingers []string            // Fingers with the highest error rates, worst first
+	ErrorFocus  metrics.ErrorClass  // Dominant error class, Unclassified if none stands out
+	SlowNGrams  []string            // Bigrams and trigrams with the slowest transitions, slowest first
+	Difficulty  Difficulty          // Length and style of the text, zero for the default
+}
+
+// describe renders the hints as prompt lines, one per kind of weakness
+func (h PracticeHints) describe() string {
+	var b strings.Builder
+
+	if len(h.ErrorChars) > 0 {
+		b.WriteString(fmt.Sprintf("User made mistakes typing these characters: %v\n", h.ErrorChars))
+	}
+
+	if len(h.ErrorWords) > 0 {
+		b.WriteString(fmt.Sprintf("User had trouble with these words: %v\n", h.ErrorWords))
+	}
+
+	if len(h.Confusions) > 0 {
+		swaps := make([]string, len(h.Confusions))
+		for i, c := range h.Confusions {
+			swaps[i] = fmt.Sprintf("%q instead of %q", c.Typed, c.Expected)
+		}
+		b.WriteString(fmt.Sprintf("User often typed the wrong character: %s\n", strings.Join(swaps, ", ")))
+	}
+
+	if len(h.WeakFingers) > 0 {
+		b.WriteString(fmt.Sprintf("User's weakest fingers: %s\n", strings.Join(h.WeakFingers, ", ")))
+	}
+
+	if len(h.SlowNGrams) > 0 {
+		b.WriteString(fmt.Sprintf("User is slow at typing these letter sequences: %s\n", strings.Join(h.SlowNGrams, ", ")))
+	}
+
+	switch h.ErrorFocus {
+	case metrics.SameFinger:
+		b.WriteString("Most mistakes are reach errors with the right finger: favour words that move the weak fingers between rows\n")
+	case metrics.WrongHand:
+		b.WriteString("Most mistakes hit a key on the wrong hand: favour words that alternate between hands\n")
+	case metrics.WrongFinger:
+		b.WriteString("Most mistakes use the wrong finger of the right hand: favour words with neighbouring keys on one hand\n")
+	}
+
+	return b.String()
+}
+
+// HintsFromAnalysis derives the confusion, finger and error class hints from a finger analysis
+func HintsFromAnalysis(confusions map[string]map[string]int, analysis metrics.FingerAnalysis) PracticeHints {
+	hints := PracticeHints{
+		Confusions: metrics.TopConfusions(confusions, 5),
+		ErrorFocus: dominantClass(analysis.Classes),
+	}
+	for _, finger := range analysis.FingersByErrorRate(3) {
+		hints.WeakFingers = append(hints.WeakFingers, finger.String())
+	}
+	return hints
+}
+
+// dominantClass returns the class holding more than half of the classified errors
+func dominantClass(classes map[metrics.ErrorClass]int) metrics.ErrorClass {
+	total := 0
+	for class, count := range classes {
+		if class != metrics.Unclassified {
+			total += count
+		}
+	}
+	for _, class := range []metrics.ErrorClass{metrics.SameFinger, metrics.WrongFinger, metrics.WrongHand} {
+		if total >= 3 && classes[class]*2 > total {
+			return class
+		}
+	}
+	return metrics.Unclassified
+}
@@ -0,0 +1,74 @@
+package sources
+
+import (
+	"go-touch/internal/metrics"
+	"strings"
+	"testing"
+)
+
+func TestAdaptivePrompt(t *testing.T) {
+	hints := PracticeHints{
+		ErrorChars:  []rune{'e'},
+		ErrorWords:  []string{"there"},
+		Confusions:  []metrics.Confusion{{Expected: 'e', Typed: 'r', Count: 3}},
+		WeakFingers: []string{"left middle"},
+		ErrorFocus:  metrics.WrongHand,
+	}
+
+	prompt := adaptivePrompt("The cat sat.", hints)
+
+	for _, want := range []string{
+		`Previous sentence: "The cat sat."`,
+		"User had trouble with these words: [there]",
+		`'r' instead of 'e'`,
+		"User's weakest fingers: left middle",
+		"alternate between hands",
+		"Only output the sentence, nothing else.",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("adaptivePrompt() missing %q", want)
+		}
+	}
+}
+
+func TestAdaptivePrompt_NoHints(t *testing.T) {
+	prompt := adaptivePrompt("The cat sat.", PracticeHints{})
+	if strings.Contains(prompt, "User") {
+		t.Errorf("adaptivePrompt() without hints should not describe the user, got %q", prompt)
+	}
+}
+
+func TestDominantClass(t *testing.T) {
+	tests := []struct {
+		name    string
+		classes map[metrics.ErrorClass]int
+		want    metrics.ErrorClass
+	}{
+		{"no errors", nil, metrics.Unclassified},
+		{"too few errors", map[metrics.ErrorClass]int{metrics.SameFinger: 2}, metrics.Unclassified},
+		{"reach errors dominate", map[metrics.ErrorClass]int{metrics.SameFinger: 4, metrics.WrongHand: 1}, metrics.SameFinger},
+		{"no majority", map[metrics.ErrorClass]int{metrics.SameFinger: 2, metrics.WrongHand: 2}, metrics.Unclassified},
+		{"unclassified ignored", map[metrics.ErrorClass]int{metrics.WrongHand: 3, metrics.Unclassified: 10}, metrics.WrongHand},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dominantClass(tt.classes); got != tt.want {
+				t.Errorf("dominantClass() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdaptivePrompt_Difficulty(t *testing.T) {
+	if prompt := adaptivePrompt("", PracticeHints{}); !strings.Contains(prompt, "(50-80 characters)") || strings.Contains(prompt, "Style:") {
+		t.Errorf("adaptivePrompt() without difficulty should ask for the default length only, got %q", prompt)
+	}
+
+	prompt := adaptivePrompt("", PracticeHints{Difficulty: DifficultyLevels[len(DifficultyLevels)-1]})
+	for _, want := range []string{"(70-110 characters)", "uncommon words", "plenty of punctuation", "number"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("adaptivePrompt() at the hardest level missing %q", want)
+		}
+	}
+}
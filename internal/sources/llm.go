@@ -116,34 +116,43 @@ Only output the sentence, nothing else.`, randomLetter)
 }
 
 func (l *LLMSource) GetNextSentence(previousSentence string, errorChars []rune, errorWords []string) (string, error) {
+	return l.GetAdaptiveSentence(previousSentence, PracticeHints{ErrorChars: errorChars, ErrorWords: errorWords})
+}
+
+// GetAdaptiveSentence generates the next sentence targeting the weaknesses described by hints
+func (l *LLMSource) GetAdaptiveSentence(previousSentence string, hints PracticeHints) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
 	defer cancel()
 
-	// Build prompt with context and error patterns
-	var promptBuilder strings.Builder
-	promptBuilder.WriteString(fmt.Sprintf("Previous sentence: \"%s\"\n\n", previousSentence))
-
-	if len(errorChars) > 0 {
-		promptBuilder.WriteString(fmt.Sprintf("User made mistakes typing these characters: %v\n", errorChars))
+	response, err := llms.GenerateFromSinglePrompt(ctx, l.model, adaptivePrompt(previousSentence, hints))
+	if err != nil {
+		return "", fmt.Errorf("API call failed: %w", err)
 	}
 
-	if len(errorWords) > 0 {
-		promptBuilder.WriteString(fmt.Sprintf("User had trouble with these words: %v\n", errorWords))
-	}
+	return strings.TrimSpace(response), nil
+}
 
-	promptBuilder.WriteString(`
-Generate ONE sentence (50-80 characters) that:
+// adaptivePrompt builds the follow-up sentence prompt from the previous sentence and the user's weaknesses
+func adaptivePrompt(previousSentence string, hints PracticeHints) string {
+	var promptBuilder strings.Builder
+	promptBuilder.WriteString(fmt.Sprintf("Previous sentence: \"%s\"\n\n", previousSentence))
+	promptBuilder.WriteString(hints.describe())
+
+	minLength, maxLength := hints.Difficulty.length()
+	promptBuilder.WriteString(fmt.Sprintf(`
+Generate ONE sentence (%d-%d characters) that:
 1. Continues naturally from the previous sentence
 2. Helps practice the problem characters and similar words
 3. Maintains topic coherence with the previous sentence
 4. Is interesting and natural to read
+`, minLength, maxLength))
 
-Only output the sentence, nothing else.`)
-
-	response, err := llms.GenerateFromSinglePrompt(ctx, l.model, promptBuilder.String())
-	if err != nil {
-		return "", fmt.Errorf("API call failed: %w", err)
+	if style := hints.Difficulty.describe(); style != "" {
+		promptBuilder.WriteString("\n" + style)
 	}
 
-	return strings.TrimSpace(response), nil
+	promptBuilder.WriteString(`
+Only output the sentence, nothing else.`)
+
+	return promptBuilder.String()
 }
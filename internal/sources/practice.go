@@ -0,0 +1,145 @@
+package sources
+
+import (
+	"fmt"
+	"go-touch/internal/metrics"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// PracticeSource generates word sequences offline from a built-in vocabulary,
+// steering them towards the user's slow n-grams and error characters
+type PracticeSource struct {
+	rng   *rand.Rand
+	words []string
+}
+
+// NewPracticeSource creates a practice source using the built-in word list
+func NewPracticeSource() *PracticeSource {
+	return &PracticeSource{
+		rng:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		words: commonWords,
+	}
+}
+
+func (p *PracticeSource) GetText() (string, error) {
+	return p.sentence(nil, Difficulty{}), nil
+}
+
+// GetAdaptiveSentence returns a sentence where about half the words contain
+// the hinted slow n-grams, error characters or problem words
+func (p *PracticeSource) GetAdaptiveSentence(previousSentence string, hints PracticeHints) (string, error) {
+	var targeted []string
+	targeted = append(targeted, hints.ErrorWords...)
+	for _, word := range p.words {
+		if practiceScore(word, hints) > 0 {
+			targeted = append(targeted, word)
+		}
+	}
+	return p.sentence(targeted, hints.Difficulty), nil
+}
+
+// practiceScore counts how many hinted weaknesses a word exercises
+func practiceScore(word string, hints PracticeHints) int {
+	score := 0
+	for _, ngram := range hints.SlowNGrams {
+		if strings.Contains(word, ngram) {
+			score += 2
+		}
+	}
+	for _, char := range hints.ErrorChars {
+		if strings.ContainsRune(word, char) {
+			score++
+		}
+	}
+	return score
+}
+
+// sentence builds one lowercase sentence of the difficulty's length,
+// alternating between targeted and random words
+func (p *PracticeSource) sentence(targeted []string, difficulty Difficulty) string {
+	if difficulty.isZero() {
+		difficulty = DifficultyLevels[DefaultDifficultyLevel]
+	}
+	vocabulary := p.vocabulary(difficulty.Vocabulary)
+	length := difficulty.MinLength + p.rng.Intn(difficulty.MaxLength-difficulty.MinLength+1)
+
+	var words []string
+	size := 0
+	for i := 0; size < length; i++ {
+		var word string
+		switch {
+		case difficulty.Numbers && p.rng.Intn(6) == 0:
+			word = p.number()
+		case len(targeted) > 0 && i%2 == 0:
+			word = targeted[p.rng.Intn(len(targeted))]
+		default:
+			word = vocabulary[p.rng.Intn(len(vocabulary))]
+		}
+		word = p.punctuate(word, difficulty.Punctuation)
+		words = append(words, word)
+		size += len(word) + 1
+	}
+
+	end := "."
+	if difficulty.Punctuation == PunctuationHeavy {
+		end = []string{".", "!", "?"}[p.rng.Intn(3)]
+	}
+	return strings.TrimRight(strings.Join(words, " "), ",;:") + end
+}
+
+const (
+	// commonVocabulary is the number of leading, most frequent words used for common vocabulary
+	commonVocabulary = 100
+	// rareVocabulary is the frequency rank from which words count as rare vocabulary
+	rareVocabulary = 200
+)
+
+// vocabulary returns the word pool for a vocabulary level
+func (p *PracticeSource) vocabulary(level int) []string {
+	if len(p.words) <= rareVocabulary {
+		return p.words
+	}
+	switch level {
+	case VocabularyCommon:
+		return p.words[:commonVocabulary]
+	case VocabularyRare:
+		return p.words[rareVocabulary:]
+	default:
+		return p.words
+	}
+}
+
+// punctuate randomly adds punctuation after or around a word
+func (p *PracticeSource) punctuate(word string, density int) string {
+	switch density {
+	case PunctuationLight:
+		if p.rng.Intn(8) == 0 {
+			return word + ","
+		}
+	case PunctuationHeavy:
+		switch p.rng.Intn(10) {
+		case 0, 1:
+			return word + ","
+		case 2:
+			return word + []string{";", ":"}[p.rng.Intn(2)]
+		case 3:
+			return "\"" + word + "\""
+		case 4:
+			return "(" + word + ")"
+		}
+	}
+	return word
+}
+
+// number returns a random number or symbol token such as "42", "$15", "80%", "#7" or "4*6"
+func (p *PracticeSource) number() string {
+	n := p.rng.Intn(100) + 1
+	return []string{fmt.Sprint(n * 10), fmt.Sprintf("$%d", n), fmt.Sprintf("%d%%", n), fmt.Sprintf("#%d", n), fmt.Sprintf("%d*%d", n, n%9+2)}[p.rng.Intn(5)]
+}
+
+// SampleWords returns up to limit words from the built-in vocabulary containing ngram
+func SampleWords(ngram string, limit int) []string {
+	return metrics.WordsContaining(strings.Join(commonWords, " "), ngram, limit)
+}
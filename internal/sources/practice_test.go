@@ -0,0 +1,133 @@
+package sources
+
+import (
+	"go-touch/internal/types"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestPracticeSource_GetText(t *testing.T) {
+	source := NewPracticeSource()
+
+	text, err := source.GetText()
+	if err != nil {
+		t.Fatalf("GetText() error: %v", err)
+	}
+	level := DifficultyLevels[DefaultDifficultyLevel]
+	if len(text) < level.MinLength {
+		t.Errorf("GetText() = %q, want at least %d characters", text, level.MinLength)
+	}
+	if !strings.HasSuffix(text, ".") {
+		t.Errorf("GetText() = %q, want a full stop at the end", text)
+	}
+}
+
+func TestPracticeSource_GetAdaptiveSentence(t *testing.T) {
+	source := &PracticeSource{rng: rand.New(rand.NewSource(1)), words: commonWords}
+
+	text, err := source.GetAdaptiveSentence("", PracticeHints{SlowNGrams: []string{"qu"}})
+	if err != nil {
+		t.Fatalf("GetAdaptiveSentence() error: %v", err)
+	}
+
+	// Every other word is drawn from the words containing the slow n-gram
+	words := strings.Fields(strings.TrimSuffix(text, "."))
+	for i := 0; i < len(words); i += 2 {
+		if !strings.Contains(words[i], "qu") {
+			t.Errorf("word %d = %q, want a word containing %q", i, words[i], "qu")
+		}
+	}
+}
+
+func TestPracticeScore(t *testing.T) {
+	hints := PracticeHints{SlowNGrams: []string{"th"}, ErrorChars: []rune{'e'}}
+
+	tests := []struct {
+		word string
+		want int
+	}{
+		{"the", 3},
+		{"with", 2},
+		{"level", 1},
+		{"box", 0},
+	}
+
+	for _, tt := range tests {
+		if got := practiceScore(tt.word, hints); got != tt.want {
+			t.Errorf("practiceScore(%q) = %d, want %d", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestNewTextSource_Practice(t *testing.T) {
+	source, err := NewTextSource("practice", types.TextConfig{})
+	if err != nil {
+		t.Fatalf("NewTextSource() error: %v", err)
+	}
+	if _, ok := source.(AdaptiveSource); !ok {
+		t.Errorf("NewTextSource(practice) returned %T, want an AdaptiveSource", source)
+	}
+}
+
+func TestCommonWords_Unique(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, word := range commonWords {
+		if seen[word] {
+			t.Errorf("commonWords lists %q more than once", word)
+		}
+		seen[word] = true
+	}
+
+	// The rare tier holds the least frequent words only
+	source := &PracticeSource{words: commonWords}
+	common := source.vocabulary(VocabularyCommon)
+	for _, word := range source.vocabulary(VocabularyRare) {
+		if containsWord(common, word) {
+			t.Errorf("rare vocabulary includes the common word %q", word)
+		}
+	}
+	if rare := source.vocabulary(VocabularyRare); len(rare) != len(commonWords)-rareVocabulary {
+		t.Errorf("rare vocabulary has %d words, want the %d after rank %d", len(rare), len(commonWords)-rareVocabulary, rareVocabulary)
+	}
+}
+
+func TestPracticeSource_Difficulty(t *testing.T) {
+	source := &PracticeSource{rng: rand.New(rand.NewSource(1)), words: commonWords}
+
+	easy := DifficultyLevels[0]
+	for i := 0; i < 20; i++ {
+		text, _ := source.GetAdaptiveSentence("", PracticeHints{Difficulty: easy})
+		// The last word may run past the maximum
+		if len(text) < easy.MinLength || len(text) > easy.MaxLength+15 {
+			t.Errorf("easy sentence %q has %d characters, want about %d-%d", text, len(text), easy.MinLength, easy.MaxLength)
+		}
+		if strings.ContainsAny(strings.TrimSuffix(text, "."), ",;:\"()") {
+			t.Errorf("easy sentence %q should have no punctuation", text)
+		}
+		for _, word := range strings.Fields(strings.TrimSuffix(text, ".")) {
+			if !containsWord(commonWords[:commonVocabulary], word) {
+				t.Errorf("easy sentence %q uses %q outside the common vocabulary", text, word)
+			}
+		}
+	}
+
+	hardest := DifficultyLevels[len(DifficultyLevels)-1]
+	var hard strings.Builder
+	for i := 0; i < 20; i++ {
+		text, _ := source.GetAdaptiveSentence("", PracticeHints{Difficulty: hardest})
+		hard.WriteString(text)
+	}
+	if !strings.ContainsAny(hard.String(), "0123456789") || !strings.ContainsAny(hard.String(), ",;:\"(") {
+		t.Errorf("hard sentences %q should contain numbers and punctuation", hard.String())
+	}
+}
+
+func containsWord(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
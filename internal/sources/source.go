@@ -9,6 +9,27 @@ type TextSource interface {
 	GetText() (string, error)
 }
 
+// Source type names recorded with each session
+const (
+	SourceDummy    = "dummy"
+	SourceLLM      = "llm"
+	SourcePractice = "practice"
+)
+
+// SourceName returns the type name of a text source, "" for nil or unknown sources
+func SourceName(source TextSource) string {
+	switch source.(type) {
+	case *DummySource:
+		return SourceDummy
+	case *LLMSource:
+		return SourceLLM
+	case *PracticeSource:
+		return SourcePractice
+	default:
+		return ""
+	}
+}
+
 func NewTextSource(sourceType string, config types.TextConfig) (TextSource, error) {
 	switch sourceType {
 	case "dummy", "Dummy", "dummy_source", "DummySource":
@@ -23,6 +44,8 @@ func NewTextSource(sourceType string, config types.TextConfig) (TextSource, erro
 			return nil, fmt.Errorf("failed to initialize LLM source: %w", err)
 		}
 		return llmSource, nil
+	case "practice", "Practice", "practice_source", "PracticeSource":
+		return NewPracticeSource(), nil
 	// case "wiki", "Wiki", "wiki_source", "WikiSource":
 	// 	return &WikiSource{}, nil
 	default:
@@ -207,3 +207,20 @@ func TestNewTextSource_UnknownSource(t *testing.T) {
 		t.Errorf("NewTextSource() error = %q, want %q", err.Error(), expectedMsg)
 	}
 }
+
+func TestSourceName(t *testing.T) {
+	tests := []struct {
+		source TextSource
+		want   string
+	}{
+		{&DummySource{}, SourceDummy},
+		{&LLMSource{}, SourceLLM},
+		{&PracticeSource{}, SourcePractice},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := SourceName(tt.source); got != tt.want {
+			t.Errorf("SourceName(%T) = %q, want %q", tt.source, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package sources
+
+// commonWords is the offline vocabulary of the practice source, without
+// duplicates and ordered by how often the words occur in English, most
+// frequent first, so that leading and trailing slices form the vocabulary
+// tiers. The first 100 are the most frequent English words; the rest were
+// picked to cover every letter and common n-gram and ranked the same way.
+var commonWords = []string{
+	"the", "be", "to", "of", "and", "a", "in", "that", "have", "it",
+	"for", "not", "on", "with", "he", "as", "you", "do", "at", "this",
+	"but", "his", "by", "from", "they", "we", "say", "her", "she", "or",
+	"an", "will", "my", "one", "all", "would", "there", "their", "what", "so",
+	"up", "out", "if", "about", "who", "get", "which", "go", "me", "when",
+	"make", "can", "like", "time", "no", "just", "him", "know", "take", "people",
+	"into", "year", "your", "good", "some", "could", "them", "see", "other", "than",
+	"then", "now", "look", "only", "come", "its", "over", "think", "also", "back",
+	"after", "use", "two", "how", "our", "work", "first", "well", "way", "even",
+	"new", "want", "because", "any", "these", "give", "day", "most", "us", "thing",
+	"should", "very", "through", "where", "why", "great", "long", "right", "still", "world",
+	"never", "before", "between", "under", "again", "while", "young", "number", "house", "might",
+	"point", "system", "thought", "always", "place", "small", "help", "change", "question", "order",
+	"every", "early", "around", "another", "without", "next", "open", "during", "city", "water",
+	"better", "though", "kind", "children", "keep", "little", "study", "story", "nothing", "student",
+	"level", "write", "enough", "hundred", "position", "instead", "picture", "together", "party", "mention",
+	"value", "major", "strong", "section", "action", "nation", "market", "support", "bring", "morning",
+	"happen", "example", "answer", "paper", "project", "speak", "condition", "spend", "check", "seven",
+	"attention", "phone", "table", "drive", "letter", "simple", "ground", "class", "light", "night",
+	"voice", "visit", "option", "brought", "travel", "above", "green", "street", "happy", "break",
+	"plan", "drop", "sleep", "thank", "object", "join", "box", "exact", "photo", "dream",
+	"strange", "wonder", "please", "draw", "station", "strength", "yesterday", "listen", "slow", "brown",
+	"extra", "fly", "evening", "index", "judge", "size", "quick", "quite", "zero", "square",
+	"enjoy", "middle", "laugh", "wrong", "known", "text", "motion", "fix", "mix", "drink",
+	"dress", "address", "ring", "stream", "strike", "string", "island", "shoulder", "finger", "kitchen",
+	"weekend", "queen", "quiet", "battle", "bottle", "settle", "apple", "bread", "bridge", "brain",
+	"crowd", "cream", "grass", "flower", "glass", "relax", "jacket", "juice", "knife", "knock",
+	"castle", "honest", "border", "powder", "puzzle", "amaze", "frozen", "dragon", "yellow", "rough",
+	"bought", "wrist", "slip", "graph", "bake", "sing", "jump", "murder",
+}
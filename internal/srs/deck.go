@@ -0,0 +1,180 @@
+package srs
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"go-touch/internal/stats"
+)
+
+// Kind is what a card asks the user to type
+type Kind string
+
+const (
+	KindWord Kind = "word"
+	KindChar Kind = "char"
+)
+
+// intervals is the review interval of each Leitner box. A miss sends a card
+// back to the first box, due again next session; each clean review moves it
+// one box up, and a clean review in the last box retires it.
+var intervals = []time.Duration{
+	0,
+	24 * time.Hour,
+	3 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	21 * 24 * time.Hour,
+}
+
+// Card is a word or character under review
+type Card struct {
+	Kind     Kind      `json:"kind"`
+	Value    string    `json:"value"`
+	Box      int       `json:"box"` // Index into intervals
+	Due      time.Time `json:"due"`
+	Misses   int       `json:"misses"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// Deck holds all cards under review
+type Deck struct {
+	Cards []Card `json:"cards"`
+}
+
+// normalize makes words match regardless of case
+func normalize(value string) string {
+	return strings.ToLower(value)
+}
+
+// find returns the index of the card, or -1
+func (d *Deck) find(kind Kind, value string) int {
+	value = normalize(value)
+	for i, card := range d.Cards {
+		if card.Kind == kind && card.Value == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// Has reports whether the deck holds a card for value
+func (d *Deck) Has(kind Kind, value string) bool {
+	return d.find(kind, value) >= 0
+}
+
+// Due returns up to limit cards of the given kind that are due at now,
+// the most overdue and most missed first
+func (d *Deck) Due(kind Kind, now time.Time, limit int) []Card {
+	var due []Card
+	for _, card := range d.Cards {
+		if card.Kind == kind && !card.Due.After(now) {
+			due = append(due, card)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		if !due[i].Due.Equal(due[j].Due) {
+			return due[i].Due.Before(due[j].Due)
+		}
+		return due[i].Misses > due[j].Misses
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due
+}
+
+// Miss records that value was mistyped, adding it to the deck if needed and
+// moving it back to the first box so it comes up again next session
+func (d *Deck) Miss(kind Kind, value string, now time.Time) {
+	i := d.find(kind, value)
+	if i < 0 {
+		d.Cards = append(d.Cards, Card{Kind: kind, Value: normalize(value)})
+		i = len(d.Cards) - 1
+	}
+	card := &d.Cards[i]
+	card.Box = 0
+	card.Due = now
+	card.Misses++
+	card.LastSeen = now
+}
+
+// Clean records that value was typed without mistakes. Cards move up one box,
+// waiting longer before the next review, and leave the deck after the last box.
+// Values not in the deck are ignored.
+func (d *Deck) Clean(kind Kind, value string, now time.Time) {
+	i := d.find(kind, value)
+	if i < 0 {
+		return
+	}
+	card := &d.Cards[i]
+	if card.Box == len(intervals)-1 {
+		d.Cards = append(d.Cards[:i], d.Cards[i+1:]...)
+		return
+	}
+	card.Box++
+	card.Due = now.Add(intervals[card.Box])
+	card.LastSeen = now
+}
+
+// Load reads a deck from path, returning an empty deck if the file does not exist.
+// Cards damaged by hand edits are dropped when they have no value and put
+// back in range when their box does not exist
+func Load(path string) (Deck, error) {
+	var deck Deck
+	err := stats.WithLock(path, func() error {
+		var err error
+		deck, err = read(path)
+		return err
+	})
+	return deck, err
+}
+
+// Update reads the deck at path, lets fn change it and writes it back
+// atomically, holding the lock throughout so reviews saved meanwhile by
+// another instance are kept
+func Update(path string, fn func(deck *Deck)) error {
+	return stats.WithLock(path, func() error {
+		deck, err := read(path)
+		if err != nil {
+			return err
+		}
+		fn(&deck)
+		return deck.write(path)
+	})
+}
+
+// read loads the deck at path; the caller holds the lock
+func read(path string) (Deck, error) {
+	var deck Deck
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) || (err == nil && len(strings.TrimSpace(string(data))) == 0) {
+		return deck, nil
+	}
+	if err != nil {
+		return deck, err
+	}
+	err = json.Unmarshal(data, &deck)
+
+	cards := deck.Cards[:0]
+	for _, card := range deck.Cards {
+		if card.Value == "" {
+			continue
+		}
+		card.Box = min(max(card.Box, 0), len(intervals)-1)
+		cards = append(cards, card)
+	}
+	deck.Cards = cards
+	return deck, err
+}
+
+// write replaces the deck at path; the caller holds the lock
+func (d Deck) write(path string) error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	return stats.WriteFileAtomic(path, data, 0644)
+}
@@ -0,0 +1,140 @@
+package srs
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDeck_MissAndClean(t *testing.T) {
+	var deck Deck
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	deck.Miss(KindWord, "World", now)
+	if len(deck.Cards) != 1 || deck.Cards[0].Value != "world" || deck.Cards[0].Box != 0 {
+		t.Fatalf("Miss() cards = %+v, want one lowercased card in the first box", deck.Cards)
+	}
+	if due := deck.Due(KindWord, now, 10); len(due) != 1 {
+		t.Errorf("Due() after a miss = %v, want the card due right away", due)
+	}
+
+	deck.Clean(KindWord, "world", now)
+	card := deck.Cards[0]
+	if card.Box != 1 || !card.Due.Equal(now.Add(intervals[1])) {
+		t.Errorf("Clean() card = %+v, want box 1 due in %v", card, intervals[1])
+	}
+	if due := deck.Due(KindWord, now, 10); len(due) != 0 {
+		t.Errorf("Due() after a clean review = %v, want nothing due", due)
+	}
+
+	deck.Miss(KindWord, "world", now)
+	if card := deck.Cards[0]; card.Box != 0 || card.Misses != 2 {
+		t.Errorf("Miss() card = %+v, want back in box 0 with 2 misses", card)
+	}
+}
+
+func TestDeck_CleanRetiresFromLastBox(t *testing.T) {
+	deck := Deck{Cards: []Card{{Kind: KindWord, Value: "done", Box: len(intervals) - 1}}}
+
+	deck.Clean(KindWord, "done", time.Now())
+
+	if len(deck.Cards) != 0 {
+		t.Errorf("Clean() in the last box left %+v, want the card retired", deck.Cards)
+	}
+}
+
+func TestDeck_CleanIgnoresUnknown(t *testing.T) {
+	var deck Deck
+	deck.Clean(KindWord, "new", time.Now())
+	if len(deck.Cards) != 0 {
+		t.Errorf("Clean() of an unknown word added %+v, want no cards", deck.Cards)
+	}
+}
+
+func TestDeck_Due(t *testing.T) {
+	now := time.Now()
+	deck := Deck{Cards: []Card{
+		{Kind: KindWord, Value: "later", Due: now.Add(time.Hour)},
+		{Kind: KindWord, Value: "recent", Due: now.Add(-time.Hour)},
+		{Kind: KindWord, Value: "oldest", Due: now.Add(-48 * time.Hour)},
+		{Kind: KindChar, Value: "q", Due: now.Add(-72 * time.Hour)},
+	}}
+
+	due := deck.Due(KindWord, now, 10)
+	if len(due) != 2 || due[0].Value != "oldest" || due[1].Value != "recent" {
+		t.Errorf("Due() = %+v, want [oldest recent]", due)
+	}
+	if due := deck.Due(KindWord, now, 1); len(due) != 1 {
+		t.Errorf("Due() with limit 1 returned %d cards", len(due))
+	}
+}
+
+func TestDeck_UpdateAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review_deck.json")
+
+	deck, err := Load(path)
+	if err != nil || len(deck.Cards) != 0 {
+		t.Fatalf("Load() without a file = %+v, %v, want an empty deck", deck, err)
+	}
+
+	if err := Update(path, func(deck *Deck) { deck.Miss(KindChar, "q", time.Now()) }); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !loaded.Has(KindChar, "q") {
+		t.Errorf("Load() = %+v, want the saved card", loaded)
+	}
+}
+
+func TestUpdate_KeepsConcurrentReviews(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review_deck.json")
+	now := time.Now()
+
+	// Two instances finishing sessions side by side each add their own card
+	var wg sync.WaitGroup
+	for _, value := range []string{"q", "z"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := Update(path, func(deck *Deck) { deck.Miss(KindChar, value, now) }); err != nil {
+				t.Errorf("Update() error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	deck, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !deck.Has(KindChar, "q") || !deck.Has(KindChar, "z") {
+		t.Errorf("Load() = %+v, want the cards of both updates", deck.Cards)
+	}
+}
+
+func TestLoad_DropsDamagedCards(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review_deck.json")
+	damaged := `{"cards": [{"kind": "char", "value": ""}, {"kind": "word", "value": "queen", "box": 9}, {"kind": "char", "value": "q", "box": -1}]}`
+	if err := os.WriteFile(path, []byte(damaged), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	deck, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(deck.Cards) != 2 || deck.Cards[0].Value != "queen" {
+		t.Fatalf("Load() = %+v, want the card without a value dropped", deck.Cards)
+	}
+	if deck.Cards[0].Box != len(intervals)-1 || deck.Cards[1].Box != 0 {
+		t.Errorf("Load() boxes = %d, %d, want them back in range", deck.Cards[0].Box, deck.Cards[1].Box)
+	}
+	// Reviewing a clamped card must not index past the intervals
+	deck.Clean(KindWord, "queen", time.Now())
+}
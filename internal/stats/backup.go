@@ -0,0 +1,39 @@
+package stats
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+)
+
+// Number of rotating copies kept of the history file
+const maxBackups = 3
+
+// backupName returns the name of the nth most recent backup of path
+func backupName(path string, n int) string {
+	return fmt.Sprintf("%s.bak%d", path, n)
+}
+
+// rotateBackups copies path to path.bak1, shifting older copies up to path.bak3
+// and dropping the oldest. A missing or empty file is not backed up, and
+// neither is one unchanged since the last backup, so repeated opens keep the
+// older copies.
+func rotateBackups(path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) || (err == nil && len(data) == 0) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if last, err := os.ReadFile(backupName(path, 1)); err == nil && bytes.Equal(last, data) {
+		return nil
+	}
+
+	for n := maxBackups; n > 1; n-- {
+		if err := os.Rename(backupName(path, n-1), backupName(path, n)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return WriteFileAtomic(backupName(path, 1), data, 0644)
+}
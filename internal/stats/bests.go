@@ -0,0 +1,126 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+
+	"go-touch/internal/types"
+)
+
+// BestKey identifies a class of sessions with their own personal best: the
+// mode, its duration or word count, and the text source
+type BestKey struct {
+	Mode    types.TestMode `json:"mode"`
+	Setting string         `json:"setting,omitempty"` // Duration in time mode, word count in words mode
+	Source  string         `json:"source,omitempty"`
+	Drill   bool           `json:"drill,omitempty"` // Drills repeat a handful of words and only compete with drills
+}
+
+// KeyOf returns the personal best class of a session
+func KeyOf(session types.TypingSession) BestKey {
+	key := BestKey{Mode: session.SessionMode(), Source: session.Source, Drill: session.Drill}
+	switch {
+	case key.Mode == types.ModeTime && session.TargetDuration > 0:
+		key.Setting = session.TargetDuration.String()
+	case key.Mode == types.ModeWords && session.WordCount > 0:
+		key.Setting = fmt.Sprintf("%d words", session.WordCount)
+	}
+	return key
+}
+
+// settingValue orders settings numerically, so 15s comes before 1m0s and
+// 25 words before 100 words; sessions without a setting come first
+func settingValue(session types.TypingSession) int64 {
+	switch session.SessionMode() {
+	case types.ModeTime:
+		return int64(session.TargetDuration)
+	case types.ModeWords:
+		return int64(session.WordCount)
+	}
+	return 0
+}
+
+// PersonalBest is the fastest session of a class
+type PersonalBest struct {
+	Key      BestKey             `json:"key"`
+	Session  types.TypingSession `json:"session"`
+	Sessions int                 `json:"sessions"` // Sessions in the class
+}
+
+// PersonalBests returns the personal best of every class in the history,
+// ordered by mode, setting and source
+func PersonalBests(history types.UserStats) []PersonalBest {
+	bests := make(map[BestKey]*PersonalBest)
+	for _, session := range history.Sessions {
+		key := KeyOf(session)
+		best, ok := bests[key]
+		if !ok {
+			best = &PersonalBest{Key: key, Session: session}
+			bests[key] = best
+		}
+		best.Sessions++
+		if session.WPM > best.Session.WPM {
+			best.Session = session
+		}
+	}
+
+	list := make([]PersonalBest, 0, len(bests))
+	for _, best := range bests {
+		list = append(list, *best)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i].Key, list[j].Key
+		if a.Mode != b.Mode {
+			return a.Mode < b.Mode
+		}
+		if x, y := settingValue(list[i].Session), settingValue(list[j].Session); x != y {
+			return x < y
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return !a.Drill && b.Drill
+	})
+	return list
+}
+
+// Comparable returns the sessions of history in the personal best class of
+// session, in their original order. The dashboard, Best and PersonalBests all
+// group sessions this way, so they never disagree about a best.
+func Comparable(history types.UserStats, session types.TypingSession) types.UserStats {
+	key := KeyOf(session)
+	result := types.UserStats{Version: history.Version, Sessions: make([]types.TypingSession, 0)}
+	for _, s := range history.Sessions {
+		if KeyOf(s) == key {
+			result.Sessions = append(result.Sessions, s)
+		}
+	}
+	return result
+}
+
+// Best returns the best WPM among the sessions comparable with session
+func Best(history types.UserStats, session types.TypingSession) (float32, bool) {
+	var best float32
+	found := false
+	for _, s := range Comparable(history, session).Sessions {
+		if !found || s.WPM > best {
+			best = s.WPM
+			found = true
+		}
+	}
+	return best, found
+}
+
+// PreviousBest is Best over the sessions recorded before session, so a new
+// record can be compared with the one it beat. When the session is not in the
+// history yet, the whole history counts.
+func PreviousBest(history types.UserStats, session types.TypingSession) (float32, bool) {
+	earlier := history.Sessions
+	for i := len(earlier) - 1; i >= 0; i-- {
+		if earlier[i].Date.Equal(session.Date) && earlier[i].WPM == session.WPM {
+			earlier = earlier[:i]
+			break
+		}
+	}
+	return Best(types.UserStats{Sessions: earlier}, session)
+}
@@ -0,0 +1,158 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+func TestBest(t *testing.T) {
+	history := types.UserStats{
+		Sessions: []types.TypingSession{
+			{WPM: 80},
+			{WPM: 60, Mode: types.ModeWords, WordCount: 25},
+			{WPM: 70, Mode: types.ModeWords, WordCount: 50},
+			{WPM: 40, Mode: types.ModeSuddenDeath},
+			{WPM: 90, Mode: types.ModeTime, TargetDuration: 15 * time.Second, Source: "llm"},
+			{WPM: 55, Mode: types.ModeTime, TargetDuration: 10 * time.Minute, Source: "llm"},
+			{WPM: 65, Mode: types.ModeTime, TargetDuration: 10 * time.Minute, Source: "dummy"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		session types.TypingSession
+		want    float32
+		wantOk  bool
+	}{
+		{"legacy sessions only compete with each other", types.TypingSession{Mode: types.ModeTime}, 80, true},
+		{"words mode keyed by word count", types.TypingSession{Mode: types.ModeWords, WordCount: 25}, 60, true},
+		{"sudden death", types.TypingSession{Mode: types.ModeSuddenDeath}, 40, true},
+		{"no sessions in mode", types.TypingSession{Mode: types.ModeZen}, 0, false},
+		{"sprint apart from long runs", types.TypingSession{Mode: types.ModeTime, TargetDuration: 10 * time.Minute, Source: "llm"}, 55, true},
+		{"keyed by source", types.TypingSession{Mode: types.ModeTime, TargetDuration: 10 * time.Minute, Source: "dummy"}, 65, true},
+		{"no sessions with duration", types.TypingSession{Mode: types.ModeTime, TargetDuration: time.Minute, Source: "llm"}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Best(history, tt.session)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("Best() = (%v, %v), want (%v, %v)", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestComparable(t *testing.T) {
+	history := types.UserStats{Sessions: []types.TypingSession{
+		{WPM: 40, Mode: types.ModeTime, TargetDuration: time.Minute, Source: "dummy"},
+		{WPM: 60, Mode: types.ModeTime, TargetDuration: 30 * time.Minute, Source: "llm"},
+		{WPM: 50, Mode: types.ModeTime, TargetDuration: time.Minute, Source: "llm"},
+		{WPM: 45, Mode: types.ModeTime, TargetDuration: time.Minute},
+		{WPM: 35},
+		{WPM: 70, Mode: types.ModeWords, WordCount: 25, Source: "llm"},
+		{WPM: 80, Mode: types.ModeWords, WordCount: 50, Source: "llm"},
+	}}
+
+	tests := []struct {
+		name    string
+		session types.TypingSession
+		want    []float32
+	}{
+		{"time mode", types.TypingSession{Mode: types.ModeTime, TargetDuration: time.Minute, Source: "llm"}, []float32{50}},
+		{"words mode", types.TypingSession{Mode: types.ModeWords, WordCount: 25, Source: "llm"}, []float32{70}},
+		{"untagged source", types.TypingSession{Mode: types.ModeTime, TargetDuration: time.Minute}, []float32{45}},
+		{"legacy session", types.TypingSession{}, []float32{35}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Comparable(history, tt.session)
+			if len(got.Sessions) != len(tt.want) {
+				t.Fatalf("Comparable() = %+v, want WPMs %v", got.Sessions, tt.want)
+			}
+			for i, wpm := range tt.want {
+				if got.Sessions[i].WPM != wpm {
+					t.Errorf("session %d WPM = %v, want %v", i, got.Sessions[i].WPM, wpm)
+				}
+			}
+			// Every comparable session shares the personal best class
+			for _, s := range got.Sessions {
+				if KeyOf(s) != KeyOf(tt.session) {
+					t.Errorf("Comparable() returned %+v outside class %+v", s, KeyOf(tt.session))
+				}
+			}
+		})
+	}
+}
+
+func TestBest_ExcludesDrills(t *testing.T) {
+	history := types.UserStats{Sessions: []types.TypingSession{
+		{WPM: 90, Mode: types.ModeText, Drill: true},
+		{WPM: 60, Mode: types.ModeText},
+	}}
+
+	if best, _ := Best(history, types.TypingSession{Mode: types.ModeText}); best != 60 {
+		t.Errorf("Best() = %v, want 60 (drills excluded)", best)
+	}
+}
+
+func TestPreviousBest(t *testing.T) {
+	at := func(minute int) time.Time { return time.Date(2025, 5, 12, 9, minute, 0, 0, time.UTC) }
+	current := types.TypingSession{Date: at(30), WPM: 75, Mode: types.ModeWords, WordCount: 25, Source: "llm"}
+	history := types.UserStats{Sessions: []types.TypingSession{
+		{Date: at(0), WPM: 60, Mode: types.ModeWords, WordCount: 25, Source: "llm"},
+		{Date: at(10), WPM: 70, Mode: types.ModeWords, WordCount: 25, Source: "llm"},
+		{Date: at(20), WPM: 95, Mode: types.ModeWords, WordCount: 10, Source: "llm"},
+		current,
+	}}
+
+	if best, ok := PreviousBest(history, current); !ok || best != 70 {
+		t.Errorf("PreviousBest() = (%v, %v), want (70, true)", best, ok)
+	}
+	// Not recorded yet: every earlier session counts
+	if best, ok := PreviousBest(types.UserStats{Sessions: history.Sessions[:3]}, current); !ok || best != 70 {
+		t.Errorf("PreviousBest() before saving = (%v, %v), want (70, true)", best, ok)
+	}
+	// The first run of its kind has nothing to beat
+	first := types.UserStats{Sessions: []types.TypingSession{current}}
+	if _, ok := PreviousBest(first, current); ok {
+		t.Error("PreviousBest() of the first session should find no best")
+	}
+}
+
+func TestPersonalBests(t *testing.T) {
+	history := types.UserStats{Sessions: []types.TypingSession{
+		{WPM: 50, Mode: types.ModeTime, TargetDuration: time.Minute, Source: "llm"},
+		{WPM: 70, Mode: types.ModeTime, TargetDuration: time.Minute, Source: "llm"},
+		{WPM: 90, Mode: types.ModeTime, TargetDuration: 15 * time.Second, Source: "llm"},
+		{WPM: 45, Mode: types.ModeTime, TargetDuration: 10 * time.Minute, Source: "llm"},
+		{WPM: 55, Mode: types.ModeWords, WordCount: 100},
+		{WPM: 60, Mode: types.ModeWords, WordCount: 25},
+		{WPM: 99, Mode: types.ModeWords, WordCount: 25, Drill: true},
+	}}
+
+	bests := PersonalBests(history)
+	want := []struct {
+		key      BestKey
+		wpm      float32
+		sessions int
+	}{
+		{BestKey{Mode: types.ModeTime, Setting: "15s", Source: "llm"}, 90, 1},
+		{BestKey{Mode: types.ModeTime, Setting: "1m0s", Source: "llm"}, 70, 2},
+		{BestKey{Mode: types.ModeTime, Setting: "10m0s", Source: "llm"}, 45, 1},
+		{BestKey{Mode: types.ModeWords, Setting: "25 words"}, 60, 1},
+		{BestKey{Mode: types.ModeWords, Setting: "25 words", Drill: true}, 99, 1},
+		{BestKey{Mode: types.ModeWords, Setting: "100 words"}, 55, 1},
+	}
+	if len(bests) != len(want) {
+		t.Fatalf("PersonalBests() = %+v, want %d classes", bests, len(want))
+	}
+	for i, w := range want {
+		if bests[i].Key != w.key || bests[i].Session.WPM != w.wpm || bests[i].Sessions != w.sessions {
+			t.Errorf("PersonalBests()[%d] = %+v %v WPM of %d, want %+v %v WPM of %d",
+				i, bests[i].Key, bests[i].Session.WPM, bests[i].Sessions, w.key, w.wpm, w.sessions)
+		}
+	}
+}
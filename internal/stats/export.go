@@ -0,0 +1,221 @@
+package stats
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+// Format is a file format the history can be exported to
+type Format string
+
+const (
+	FormatCSV      Format = "csv"      // One row per session with every scalar field
+	FormatJSON     Format = "json"     // The whole history including per-key and per-word details
+	FormatMarkdown Format = "markdown" // A readable table of the main figures
+)
+
+// ParseFormat checks an export format given on the command line
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case FormatCSV, FormatJSON, FormatMarkdown:
+		return format, nil
+	case "md":
+		return FormatMarkdown, nil
+	}
+	return "", fmt.Errorf("unknown format %q, want csv, json or markdown", name)
+}
+
+// csvColumn maps one CSV column to a session field, in both directions so
+// exported files can be imported again
+type csvColumn struct {
+	name string
+	get  func(s types.TypingSession) string
+	set  func(s *types.TypingSession, value string) error
+}
+
+// csvColumns lists the columns of a CSV export in order
+var csvColumns = []csvColumn{
+	{"date",
+		func(s types.TypingSession) string { return s.Date.Format(time.RFC3339) },
+		func(s *types.TypingSession, v string) error { return parseDate(v, &s.Date) }},
+	{"mode",
+		func(s types.TypingSession) string { return string(s.Mode) },
+		func(s *types.TypingSession, v string) error { s.Mode = types.TestMode(v); return nil }},
+	{"word_count",
+		func(s types.TypingSession) string { return strconv.Itoa(s.WordCount) },
+		func(s *types.TypingSession, v string) error { return parseInt(v, &s.WordCount) }},
+	{"target_duration_seconds",
+		func(s types.TypingSession) string { return formatSeconds(s.TargetDuration) },
+		func(s *types.TypingSession, v string) error { return parseSeconds(v, &s.TargetDuration) }},
+	{"wpm",
+		func(s types.TypingSession) string { return formatFloat(s.WPM) },
+		func(s *types.TypingSession, v string) error { return parseFloat(v, &s.WPM) }},
+	{"gross_wpm",
+		func(s types.TypingSession) string { return formatFloat(s.GrossWPM) },
+		func(s *types.TypingSession, v string) error { return parseFloat(v, &s.GrossWPM) }},
+	{"net_wpm",
+		func(s types.TypingSession) string { return formatFloat(s.NetWPM) },
+		func(s *types.TypingSession, v string) error { return parseFloat(v, &s.NetWPM) }},
+	{"raw_wpm",
+		func(s types.TypingSession) string { return formatFloat(s.RawWPM) },
+		func(s *types.TypingSession, v string) error { return parseFloat(v, &s.RawWPM) }},
+	{"accuracy",
+		func(s types.TypingSession) string { return formatFloat(s.Accuracy) },
+		func(s *types.TypingSession, v string) error { return parseFloat(v, &s.Accuracy) }},
+	{"errors",
+		func(s types.TypingSession) string { return strconv.Itoa(s.Errors) },
+		func(s *types.TypingSession, v string) error { return parseInt(v, &s.Errors) }},
+	{"corrected_errors",
+		func(s types.TypingSession) string { return strconv.Itoa(s.CorrectedErrors) },
+		func(s *types.TypingSession, v string) error { return parseInt(v, &s.CorrectedErrors) }},
+	{"uncorrected_errors",
+		func(s types.TypingSession) string { return strconv.Itoa(s.UncorrectedErrors) },
+		func(s *types.TypingSession, v string) error { return parseInt(v, &s.UncorrectedErrors) }},
+	{"consistency",
+		func(s types.TypingSession) string { return formatFloat(s.Consistency) },
+		func(s *types.TypingSession, v string) error { return parseFloat(v, &s.Consistency) }},
+	{"duration_seconds",
+		func(s types.TypingSession) string { return formatSeconds(s.Duration) },
+		func(s *types.TypingSession, v string) error { return parseSeconds(v, &s.Duration) }},
+	{"paused_seconds",
+		func(s types.TypingSession) string { return formatSeconds(s.PausedTime) },
+		func(s *types.TypingSession, v string) error { return parseSeconds(v, &s.PausedTime) }},
+	{"drill",
+		func(s types.TypingSession) string { return strconv.FormatBool(s.Drill) },
+		func(s *types.TypingSession, v string) error { return parseBool(v, &s.Drill) }},
+	{"source",
+		func(s types.TypingSession) string { return s.Source },
+		func(s *types.TypingSession, v string) error { s.Source = v; return nil }},
+	{"provider",
+		func(s types.TypingSession) string { return s.Provider },
+		func(s *types.TypingSession, v string) error { s.Provider = v; return nil }},
+	{"model",
+		func(s types.TypingSession) string { return s.Model },
+		func(s *types.TypingSession, v string) error { s.Model = v; return nil }},
+	{"keyboard_layout",
+		func(s types.TypingSession) string { return s.KeyboardLayout },
+		func(s *types.TypingSession, v string) error { s.KeyboardLayout = v; return nil }},
+	{"theme",
+		func(s types.TypingSession) string { return s.Theme },
+		func(s *types.TypingSession, v string) error { s.Theme = v; return nil }},
+	{"app_version",
+		func(s types.TypingSession) string { return s.AppVersion },
+		func(s *types.TypingSession, v string) error { s.AppVersion = v; return nil }},
+	{"text_hash",
+		func(s types.TypingSession) string { return s.TextHash },
+		func(s *types.TypingSession, v string) error { s.TextHash = v; return nil }},
+}
+
+func formatFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+func parseDate(value string, into *time.Time) (err error) {
+	*into, err = time.Parse(time.RFC3339, value)
+	return err
+}
+
+func parseBool(value string, into *bool) (err error) {
+	*into, err = strconv.ParseBool(value)
+	return err
+}
+
+func parseInt(value string, into *int) (err error) {
+	*into, err = strconv.Atoi(value)
+	return err
+}
+
+func parseFloat(value string, into *float32) error {
+	f, err := strconv.ParseFloat(value, 32)
+	*into = float32(f)
+	return err
+}
+
+func parseSeconds(value string, into *time.Duration) error {
+	seconds, err := strconv.ParseFloat(value, 64)
+	*into = time.Duration(seconds * float64(time.Second))
+	return err
+}
+
+// Export writes the history in the given format
+func Export(w io.Writer, stats types.UserStats, format Format) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	case FormatCSV:
+		return exportCSV(w, stats.Sessions)
+	case FormatMarkdown:
+		return exportMarkdown(w, stats.Sessions)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+func exportCSV(w io.Writer, sessions []types.TypingSession) error {
+	writer := csv.NewWriter(w)
+	header := make([]string, len(csvColumns))
+	for i, column := range csvColumns {
+		header[i] = column.name
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	row := make([]string, len(csvColumns))
+	for _, session := range sessions {
+		for i, column := range csvColumns {
+			row[i] = column.get(session)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func exportMarkdown(w io.Writer, sessions []types.TypingSession) error {
+	var b strings.Builder
+	b.WriteString("| Date | Mode | Source | WPM | Accuracy | Errors | Duration |\n")
+	b.WriteString("|------|------|--------|----:|---------:|-------:|---------:|\n")
+	for _, session := range sessions {
+		source := session.Source
+		if source == "" {
+			source = "-"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %.1f | %.1f%% | %d | %s |\n",
+			session.Date.Local().Format("2006-01-02 15:04"), modeName(session), source,
+			session.WPM, session.Accuracy, session.Errors, session.Duration.Round(time.Second))
+	}
+	total := Summarize(sessions)
+	fmt.Fprintf(&b, "\n%d sessions, average %.1f WPM at %.1f%% accuracy, best %.1f WPM\n",
+		total.Sessions, total.AvgWPM, total.AvgAccuracy, total.BestWPM)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// modeName describes the mode of a session with its setting, e.g. "words 25"
+func modeName(session types.TypingSession) string {
+	mode := string(session.SessionMode())
+	switch {
+	case session.Drill:
+		return "drill"
+	case session.SessionMode() == types.ModeWords && session.WordCount > 0:
+		return fmt.Sprintf("%s %d", mode, session.WordCount)
+	case session.SessionMode() == types.ModeTime && session.TargetDuration > 0:
+		return fmt.Sprintf("%s %s", mode, session.TargetDuration)
+	}
+	return mode
+}
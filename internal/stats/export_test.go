@@ -0,0 +1,102 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+func exportTestSessions() []types.TypingSession {
+	return []types.TypingSession{
+		{
+			Date: time.Date(2025, 5, 12, 9, 30, 0, 0, time.UTC), Mode: types.ModeTime, TargetDuration: time.Minute,
+			WPM: 52.5, GrossWPM: 55, NetWPM: 52.5, RawWPM: 57.25, Accuracy: 96.5, Errors: 3,
+			CorrectedErrors: 2, UncorrectedErrors: 1, Consistency: 81.5, Duration: time.Minute,
+			PausedTime: 1500 * time.Millisecond, Source: "llm", Provider: "anthropic", Model: "claude-3-5-haiku-latest",
+			KeyboardLayout: "QWERTY", Theme: "default", AppVersion: "v1.2.0", TextHash: "0123456789ab",
+		},
+		{
+			Date: time.Date(2025, 5, 13, 18, 0, 0, 0, time.UTC), Mode: types.ModeWords, WordCount: 25,
+			WPM: 61, NetWPM: 61, Accuracy: 100, Duration: 25 * time.Second, Drill: true, Source: "drill",
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Format
+		wantErr bool
+	}{
+		{"csv", FormatCSV, false},
+		{"JSON", FormatJSON, false},
+		{"markdown", FormatMarkdown, false},
+		{"md", FormatMarkdown, false},
+		{"xlsx", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestExport_CSVRoundTrip(t *testing.T) {
+	sessions := exportTestSessions()
+	var out bytes.Buffer
+	if err := Export(&out, types.UserStats{Sessions: sessions}, FormatCSV); err != nil {
+		t.Fatalf("Export() unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(out.Bytes())).ReadAll()
+	if err != nil {
+		t.Fatalf("Export() wrote invalid CSV: %v", err)
+	}
+	if len(records) != 3 || len(records[0]) != len(csvColumns) {
+		t.Fatalf("Export() wrote %d rows of %d columns, want 3 of %d", len(records), len(records[0]), len(csvColumns))
+	}
+
+	imported, err := Import(&out)
+	if err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(imported, sessions) {
+		t.Errorf("Import(Export()) = %+v, want %+v", imported, sessions)
+	}
+}
+
+func TestExport_JSONRoundTrip(t *testing.T) {
+	sessions := exportTestSessions()
+	sessions[0].KeyStats = map[string]types.KeyStat{"a": {Presses: 3, Errors: 1, Latency: time.Second}}
+	sessions[0].MistypedWords = []types.MistypedWord{{Target: "the", Typed: "teh", Count: 1}}
+
+	var out bytes.Buffer
+	if err := Export(&out, types.UserStats{Version: SchemaVersion, Sessions: sessions}, FormatJSON); err != nil {
+		t.Fatalf("Export() unexpected error: %v", err)
+	}
+	imported, err := Import(&out)
+	if err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(imported, sessions) {
+		t.Errorf("Import(Export()) = %+v, want %+v", imported, sessions)
+	}
+}
+
+func TestExport_Markdown(t *testing.T) {
+	var out bytes.Buffer
+	if err := Export(&out, types.UserStats{Sessions: exportTestSessions()}, FormatMarkdown); err != nil {
+		t.Fatalf("Export() unexpected error: %v", err)
+	}
+	for _, want := range []string{"| Date | Mode |", "time 1m0s", "drill", "| 52.5 | 96.5% |", "2 sessions"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Export() markdown missing %q:\n%s", want, out.String())
+		}
+	}
+}
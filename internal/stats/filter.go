@@ -0,0 +1,59 @@
+package stats
+
+import (
+	"strings"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+// Filter selects sessions by when and how they were typed. Zero fields match
+// every session; set fields only match sessions tagged with the same value.
+type Filter struct {
+	Since          time.Time      // Sessions on or after this time
+	Mode           types.TestMode // Test mode, untagged sessions count as time mode
+	TargetDuration time.Duration  // Selected duration in time mode
+	WordCount      int            // Target word count in words mode
+	Source         string         // Text source type
+	Provider       string         // LLM provider
+	Model          string         // LLM model
+	KeyboardLayout string         // Keyboard layout name
+	TextHash       string         // Exact text typed
+	SkipIdle       bool           // Leave out sessions auto-paused because the user went idle
+}
+
+// Match reports whether the session passes the filter
+func (f Filter) Match(s types.TypingSession) bool {
+	switch {
+	case !f.Since.IsZero() && s.Date.Before(f.Since):
+		return false
+	case f.Mode != "" && s.SessionMode() != f.Mode:
+		return false
+	case f.TargetDuration != 0 && s.TargetDuration != f.TargetDuration:
+		return false
+	case f.WordCount != 0 && s.WordCount != f.WordCount:
+		return false
+	case f.SkipIdle && s.WasIdle():
+		return false
+	case !matchTag(f.Source, s.Source), !matchTag(f.Provider, s.Provider), !matchTag(f.Model, s.Model),
+		!matchTag(f.KeyboardLayout, s.KeyboardLayout), !matchTag(f.TextHash, s.TextHash):
+		return false
+	}
+	return true
+}
+
+// matchTag compares a filter value with a session tag, ignoring case
+func matchTag(want, got string) bool {
+	return want == "" || strings.EqualFold(want, got)
+}
+
+// Apply returns the sessions that pass the filter, in their original order
+func (f Filter) Apply(stats types.UserStats) types.UserStats {
+	filtered := types.UserStats{Version: stats.Version, Sessions: make([]types.TypingSession, 0, len(stats.Sessions))}
+	for _, session := range stats.Sessions {
+		if f.Match(session) {
+			filtered.Sessions = append(filtered.Sessions, session)
+		}
+	}
+	return filtered
+}
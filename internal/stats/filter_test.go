@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+func TestFilter_Match(t *testing.T) {
+	day := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	session := types.TypingSession{
+		Date:           day,
+		Mode:           types.ModeTime,
+		TargetDuration: time.Minute,
+		Source:         "llm",
+		Provider:       "anthropic",
+		Model:          "claude-3-5-haiku-latest",
+		KeyboardLayout: "QWERTY",
+		TextHash:       "abc123",
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"empty", Filter{}, true},
+		{"since before", Filter{Since: day.Add(-time.Hour)}, true},
+		{"since after", Filter{Since: day.Add(time.Hour)}, false},
+		{"mode", Filter{Mode: types.ModeTime}, true},
+		{"other mode", Filter{Mode: types.ModeWords}, false},
+		{"duration", Filter{TargetDuration: time.Minute}, true},
+		{"other duration", Filter{TargetDuration: 30 * time.Minute}, false},
+		{"source ignores case", Filter{Source: "LLM"}, true},
+		{"other source", Filter{Source: "dummy"}, false},
+		{"provider and model", Filter{Provider: "anthropic", Model: "claude-3-5-haiku-latest"}, true},
+		{"other model", Filter{Model: "gpt-4"}, false},
+		{"layout", Filter{KeyboardLayout: "qwerty"}, true},
+		{"other text", Filter{TextHash: "def456"}, false},
+		{"skip idle keeps an active session", Filter{SkipIdle: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(session); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter_SkipIdle(t *testing.T) {
+	idle := types.TypingSession{Pauses: []types.Pause{{Duration: time.Minute, Auto: true}}}
+	if (Filter{SkipIdle: true}).Match(idle) {
+		t.Error("Match() kept a session that went idle")
+	}
+	if !(Filter{}).Match(idle) {
+		t.Error("Match() dropped an idle session without SkipIdle")
+	}
+}
+
+func TestFilter_UntaggedSessions(t *testing.T) {
+	// Sessions saved before tags existed only match filters on what they recorded
+	old := types.TypingSession{WPM: 40}
+	if !(Filter{Mode: types.ModeTime}).Match(old) {
+		t.Error("Match() excluded an untagged session from time mode")
+	}
+	if (Filter{Source: "dummy"}).Match(old) {
+		t.Error("Match() matched an untagged session by source")
+	}
+}
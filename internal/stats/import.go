@@ -0,0 +1,185 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+// SourceMonkeytype tags sessions imported from a Monkeytype results export
+const SourceMonkeytype = "monkeytype"
+
+// ErrUnknownFormat marks an import file that is neither a GoTouch export nor a Monkeytype CSV
+var ErrUnknownFormat = errors.New("unrecognised import format")
+
+// Import reads sessions from a GoTouch JSON or CSV export or a Monkeytype CSV
+// results export, detected from the content
+func Import(r io.Reader) ([]types.TypingSession, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+	if trimmed[0] == '{' {
+		return importJSON(trimmed)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(trimmed)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+	}
+	header := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		header[strings.TrimSpace(name)] = i
+	}
+	switch {
+	case hasColumns(header, "timestamp", "wpm", "acc", "mode", "mode2"):
+		return importMonkeytype(header, records[1:])
+	case hasColumns(header, "date", "wpm"):
+		return importCSV(header, records[1:])
+	}
+	return nil, fmt.Errorf("%w: unexpected CSV columns %s", ErrUnknownFormat, strings.Join(records[0], ", "))
+}
+
+// hasColumns reports whether the CSV header contains every named column
+func hasColumns(header map[string]int, names ...string) bool {
+	for _, name := range names {
+		if _, ok := header[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// importJSON reads a JSON export, upgrading it from its schema version
+func importJSON(data []byte) ([]types.TypingSession, error) {
+	// Documents without a version predate schema versions
+	stats := types.UserStats{Version: 1}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+	}
+	if err := upgrade(&stats, stats.Version); err != nil {
+		return nil, err
+	}
+	return stats.Sessions, nil
+}
+
+// importCSV reads a CSV export; unknown columns are ignored and missing ones
+// left at their zero value, except that every row needs a date
+func importCSV(header map[string]int, rows [][]string) ([]types.TypingSession, error) {
+	sessions := make([]types.TypingSession, 0, len(rows))
+	for line, row := range rows {
+		var session types.TypingSession
+		for _, column := range csvColumns {
+			i, ok := header[column.name]
+			if !ok || i >= len(row) || row[i] == "" {
+				continue
+			}
+			if err := column.set(&session, row[i]); err != nil {
+				return nil, fmt.Errorf("row %d, column %s: %w", line+2, column.name, err)
+			}
+		}
+		if session.Date.IsZero() {
+			return nil, fmt.Errorf("row %d: missing date", line+2)
+		}
+		// Rows without a mode are timed, as in the version 3 migration
+		session.Mode = session.SessionMode()
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// importMonkeytype reads a Monkeytype results export. charStats holds the
+// correct, incorrect, extra and missed characters separated by semicolons;
+// mode2 is the duration in seconds for time tests and the word count for word tests.
+func importMonkeytype(header map[string]int, rows [][]string) ([]types.TypingSession, error) {
+	field := func(row []string, name string) string {
+		if i, ok := header[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	sessions := make([]types.TypingSession, 0, len(rows))
+	for line, row := range rows {
+		millis, err := strconv.ParseInt(field(row, "timestamp"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid timestamp: %w", line+2, err)
+		}
+		session := types.TypingSession{Date: time.UnixMilli(millis), Source: SourceMonkeytype}
+		if err := parseFloat(field(row, "wpm"), &session.WPM); err != nil {
+			return nil, fmt.Errorf("row %d: invalid wpm: %w", line+2, err)
+		}
+		session.NetWPM = session.WPM
+		parseFloat(field(row, "acc"), &session.Accuracy)
+		parseFloat(field(row, "rawWpm"), &session.RawWPM)
+		parseFloat(field(row, "consistency"), &session.Consistency)
+		parseSeconds(field(row, "testDuration"), &session.Duration)
+
+		if counts := strings.Split(field(row, "charStats"), ";"); len(counts) == 4 {
+			for _, count := range counts[1:] {
+				if n, err := strconv.Atoi(count); err == nil {
+					session.UncorrectedErrors += n
+				}
+			}
+			session.Errors = session.UncorrectedErrors
+		}
+
+		setting, _ := strconv.Atoi(field(row, "mode2"))
+		switch field(row, "mode") {
+		case "time":
+			session.Mode = types.ModeTime
+			session.TargetDuration = time.Duration(setting) * time.Second
+		case "words":
+			session.Mode = types.ModeWords
+			session.WordCount = setting
+		case "zen":
+			session.Mode = types.ModeZen
+		default:
+			// Quotes and custom texts end when the text is complete
+			session.Mode = types.ModeText
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// Merge adds the imported sessions to the history in date order. A session
+// is a duplicate when one started in the same second, so importing the same
+// file twice adds nothing. It returns the merged history and the number of
+// sessions added and skipped.
+func Merge(history types.UserStats, imported []types.TypingSession) (types.UserStats, int, int) {
+	seen := make(map[int64]bool, len(history.Sessions)+len(imported))
+	for _, session := range history.Sessions {
+		seen[session.Date.Unix()] = true
+	}
+
+	merged := types.UserStats{Version: SchemaVersion, Sessions: make([]types.TypingSession, 0, len(history.Sessions)+len(imported))}
+	merged.Sessions = append(merged.Sessions, history.Sessions...)
+	added, skipped := 0, 0
+	for _, session := range imported {
+		if seen[session.Date.Unix()] {
+			skipped++
+			continue
+		}
+		seen[session.Date.Unix()] = true
+		merged.Sessions = append(merged.Sessions, session)
+		added++
+	}
+	sort.SliceStable(merged.Sessions, func(i, j int) bool {
+		return merged.Sessions[i].Date.Before(merged.Sessions[j].Date)
+	})
+	return merged, added, skipped
+}
@@ -0,0 +1,133 @@
+package stats
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+func TestImport_Monkeytype(t *testing.T) {
+	file, err := os.Open(filepath.Join("testdata", "monkeytype_results.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	sessions, err := Import(file)
+	if err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+	if len(sessions) != 3 {
+		t.Fatalf("Import() returned %d sessions, want 3", len(sessions))
+	}
+
+	timed := sessions[0]
+	if !timed.Date.Equal(time.UnixMilli(1716560000000)) || timed.Source != SourceMonkeytype {
+		t.Errorf("timed session date %v, source %q", timed.Date, timed.Source)
+	}
+	if timed.WPM != 72.4 || timed.NetWPM != 72.4 || timed.RawWPM != 75.1 || timed.Accuracy != 96.5 || timed.Consistency != 81.2 {
+		t.Errorf("timed session speeds = %+v", timed)
+	}
+	if timed.Mode != types.ModeTime || timed.TargetDuration != time.Minute || timed.Duration != time.Minute {
+		t.Errorf("timed session mode %q, target %v, duration %v", timed.Mode, timed.TargetDuration, timed.Duration)
+	}
+	if timed.Errors != 11 {
+		t.Errorf("timed session errors = %d, want incorrect, extra and missed characters", timed.Errors)
+	}
+
+	if words := sessions[1]; words.Mode != types.ModeWords || words.WordCount != 25 || words.Duration != 23400*time.Millisecond {
+		t.Errorf("words session mode %q, word count %d, duration %v", words.Mode, words.WordCount, words.Duration)
+	}
+	if quote := sessions[2]; quote.Mode != types.ModeText {
+		t.Errorf("quote session mode = %q, want %q", quote.Mode, types.ModeText)
+	}
+}
+
+func TestImport_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{"unknown columns", "name,score\nalice,10\n", ErrUnknownFormat},
+		{"broken json", `{"sessions": [`, ErrUnknownFormat},
+		{"newer schema", `{"version": 99, "sessions": []}`, ErrUnsupportedVersion},
+		{"version 0", `{"version": 0, "sessions": []}`, ErrCorrupt},
+		{"negative version", `{"version": -1, "sessions": []}`, ErrCorrupt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Import(strings.NewReader(tt.content)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Import() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if sessions, err := Import(strings.NewReader("  \n")); err != nil || len(sessions) != 0 {
+		t.Errorf("Import(blank) = %v, %v, want nothing", sessions, err)
+	}
+	if _, err := Import(strings.NewReader("date,wpm\nyesterday,40\n")); err == nil || !strings.Contains(err.Error(), "row 2") {
+		t.Errorf("Import() error = %v, want the bad row reported", err)
+	}
+	if _, err := Import(strings.NewReader("date,wpm\n2025-05-12T09:00:00Z,40\n,50\n")); err == nil || !strings.Contains(err.Error(), "row 3: missing date") {
+		t.Errorf("Import() error = %v, want the row without a date reported", err)
+	}
+}
+
+func TestImport_CSVNormalisesMode(t *testing.T) {
+	sessions, err := Import(strings.NewReader("date,mode,wpm\n2025-05-12T09:00:00Z,,40\n2025-05-12T10:00:00Z,zen,50\n"))
+	if err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].Mode != types.ModeTime || sessions[1].Mode != types.ModeZen {
+		t.Errorf("Import() = %+v, want an explicit time mode and the recorded zen mode", sessions)
+	}
+}
+
+func TestImport_LegacyJSON(t *testing.T) {
+	// An export without a version is upgraded like a version 1 history
+	sessions, err := Import(strings.NewReader(`{"sessions": [{"wpm": 40}]}`))
+	if err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].Mode != types.ModeTime || sessions[0].NetWPM != 0 {
+		t.Errorf("Import() = %+v, want an upgraded time session without net WPM", sessions)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	at := func(minute int) time.Time { return time.Date(2025, 5, 12, 9, minute, 0, 0, time.UTC) }
+	history := types.UserStats{Sessions: []types.TypingSession{
+		{Date: at(0), WPM: 40},
+		{Date: at(20), WPM: 60},
+	}}
+	imported := []types.TypingSession{
+		{Date: at(20).Add(300 * time.Millisecond), WPM: 61}, // Same second as a recorded session
+		{Date: at(10), WPM: 50},
+		{Date: at(10), WPM: 50}, // Repeated within the file
+	}
+
+	merged, added, skipped := Merge(history, imported)
+	if added != 1 || skipped != 2 {
+		t.Errorf("Merge() added %d, skipped %d, want 1 and 2", added, skipped)
+	}
+	var wpms []float32
+	for _, session := range merged.Sessions {
+		wpms = append(wpms, session.WPM)
+	}
+	if len(wpms) != 3 || wpms[0] != 40 || wpms[1] != 50 || wpms[2] != 60 {
+		t.Errorf("Merge() WPMs = %v, want [40 50 60] in date order", wpms)
+	}
+	if len(history.Sessions) != 2 {
+		t.Error("Merge() modified the original history")
+	}
+
+	if _, added, _ := Merge(merged, imported); added != 0 {
+		t.Errorf("second Merge() added %d sessions, want 0", added)
+	}
+}
@@ -0,0 +1,169 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"go-touch/internal/types"
+)
+
+// JSONStore keeps the whole history in a single JSON document, the format
+// used before the JSONL store
+type JSONStore struct {
+	Path string
+}
+
+// Load reads the history upgraded to SchemaVersion, empty when the file is
+// missing or blank. A document that cannot be parsed is reported as ErrCorrupt.
+func (s *JSONStore) Load() (types.UserStats, error) {
+	var stats types.UserStats
+	err := WithLock(s.Path, func() error {
+		var err error
+		stats, err = s.read()
+		return err
+	})
+	return stats, err
+}
+
+// read parses the document; the caller holds the lock
+func (s *JSONStore) read() (types.UserStats, error) {
+	stats := types.UserStats{Version: SchemaVersion, Sessions: make([]types.TypingSession, 0)}
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return stats, nil
+	}
+
+	// Documents without a version predate schema versions
+	stats.Version = 1
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return stats, fmt.Errorf("%s: %w: %v", s.Path, ErrCorrupt, err)
+	}
+	// Ensure Sessions is never nil
+	if stats.Sessions == nil {
+		stats.Sessions = make([]types.TypingSession, 0)
+	}
+	if err := upgrade(&stats, stats.Version); err != nil {
+		return stats, fmt.Errorf("%s: %w", s.Path, err)
+	}
+	return stats, nil
+}
+
+// Append adds the session by rewriting the whole document. The lock is held
+// from read to write so sessions from other instances are not overwritten.
+func (s *JSONStore) Append(session types.TypingSession) error {
+	return WithLock(s.Path, func() error {
+		stats, err := s.read()
+		if err != nil {
+			return err
+		}
+		stats.Sessions = append(stats.Sessions, session)
+		return s.write(stats)
+	})
+}
+
+// Save writes the history as indented JSON, backing up the old document
+func (s *JSONStore) Save(stats types.UserStats) error {
+	return WithLock(s.Path, func() error {
+		return s.write(stats)
+	})
+}
+
+// Update changes the history under one lock, rewriting the document when fn
+// reports a change
+func (s *JSONStore) Update(fn func(stats *types.UserStats) bool) error {
+	return WithLock(s.Path, func() error {
+		stats, err := s.read()
+		if err != nil || !fn(&stats) {
+			return err
+		}
+		return s.write(stats)
+	})
+}
+
+// write replaces the document; the caller holds the lock
+func (s *JSONStore) write(stats types.UserStats) error {
+	stats.Version = SchemaVersion
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := rotateBackups(s.Path); err != nil {
+		return err
+	}
+	return WriteFileAtomic(s.Path, data, 0644)
+}
+
+// Compact does nothing; every save rewrites the document
+func (s *JSONStore) Compact() error {
+	return nil
+}
+
+// Backup adds the current document to the rotating backups
+func (s *JSONStore) Backup() error {
+	return WithLock(s.Path, func() error {
+		return rotateBackups(s.Path)
+	})
+}
+
+// salvage decodes sessions one by one up to the first unreadable one, counting
+// the unreadable rest as one dropped record. It also returns the schema
+// version of the document.
+func (s *JSONStore) salvage(data []byte) ([]types.TypingSession, int, int) {
+	sessions := make([]types.TypingSession, 0)
+	version := 1
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return sessions, version, 1
+	}
+
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return sessions, version, 1
+		}
+		switch key {
+		case "version":
+			if err := decoder.Decode(&version); err != nil {
+				return sessions, version, 1
+			}
+			// A damaged version counts as the oldest version
+			if !validVersion(version) {
+				version = 1
+			}
+			continue
+		case "sessions":
+		default:
+			var skip json.RawMessage
+			if err := decoder.Decode(&skip); err != nil {
+				return sessions, version, 1
+			}
+			continue
+		}
+
+		if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+			return sessions, version, 1
+		}
+		for decoder.More() {
+			var session types.TypingSession
+			if err := decoder.Decode(&session); err != nil {
+				return sessions, version, 1
+			}
+			sessions = append(sessions, session)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return sessions, version, 1
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return sessions, version, 1
+	}
+	return sessions, version, 0
+}
@@ -0,0 +1,139 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+func TestJSONStore_FileNotExists(t *testing.T) {
+	store := &JSONStore{Path: filepath.Join(t.TempDir(), "nonexistent.json")}
+
+	stats, err := store.Load()
+
+	// Should not return error for non-existent file
+	if err != nil {
+		t.Errorf("Load() unexpected error: %v", err)
+	}
+	if len(stats.Sessions) != 0 {
+		t.Errorf("Load() Sessions length = %d, want 0", len(stats.Sessions))
+	}
+}
+
+func TestJSONStore_EmptyFile(t *testing.T) {
+	statsFile := filepath.Join(t.TempDir(), "stats.json")
+
+	// Create empty file
+	if err := os.WriteFile(statsFile, []byte(""), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	stats, err := (&JSONStore{Path: statsFile}).Load()
+
+	if err != nil {
+		t.Errorf("Load() unexpected error for empty file: %v", err)
+	}
+	if stats.Sessions == nil {
+		t.Errorf("Load() Sessions should be initialized, got nil")
+	}
+}
+
+func TestJSONStore_ValidFile(t *testing.T) {
+	statsFile := filepath.Join(t.TempDir(), "stats.json")
+
+	// Create valid stats file
+	validJSON := `{
+		"sessions": [
+			{
+				"date": "2024-01-01T12:00:00Z",
+				"wpm": 50,
+				"accuracy": 95,
+				"errors": 5,
+				"duration": 60000000000
+			}
+		]
+	}`
+
+	if err := os.WriteFile(statsFile, []byte(validJSON), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	stats, err := (&JSONStore{Path: statsFile}).Load()
+
+	if err != nil {
+		t.Errorf("Load() unexpected error: %v", err)
+	}
+	if len(stats.Sessions) != 1 {
+		t.Fatalf("Load() Sessions length = %d, want 1", len(stats.Sessions))
+	}
+	if stats.Sessions[0].WPM != 50 {
+		t.Errorf("Load() Session WPM = %v, want 50", stats.Sessions[0].WPM)
+	}
+	if stats.Sessions[0].Accuracy != 95 {
+		t.Errorf("Load() Session Accuracy = %v, want 95", stats.Sessions[0].Accuracy)
+	}
+}
+
+func TestJSONStore_Save(t *testing.T) {
+	statsFile := filepath.Join(t.TempDir(), "stats.json")
+	store := &JSONStore{Path: statsFile}
+
+	stats := types.UserStats{
+		Sessions: []types.TypingSession{
+			{
+				Date:     time.Now(),
+				WPM:      45,
+				Accuracy: 92.5,
+				Errors:   8,
+				Duration: 90 * time.Second,
+			},
+		},
+	}
+
+	if err := store.Save(stats); err != nil {
+		t.Errorf("Save() unexpected error: %v", err)
+	}
+
+	// Verify file was created
+	if _, err := os.Stat(statsFile); os.IsNotExist(err) {
+		t.Errorf("Save() did not create file")
+	}
+
+	// Read back and verify
+	loadedStats, err := store.Load()
+	if err != nil {
+		t.Errorf("Load() after save unexpected error: %v", err)
+	}
+	if len(loadedStats.Sessions) != 1 {
+		t.Fatalf("Loaded stats has %d sessions, want 1", len(loadedStats.Sessions))
+	}
+	if loadedStats.Sessions[0].WPM != 45 {
+		t.Errorf("Loaded WPM = %v, want 45", loadedStats.Sessions[0].WPM)
+	}
+	if loadedStats.Sessions[0].Accuracy != 92.5 {
+		t.Errorf("Loaded Accuracy = %v, want 92.5", loadedStats.Sessions[0].Accuracy)
+	}
+	if loadedStats.Sessions[0].Errors != 8 {
+		t.Errorf("Loaded Errors = %v, want 8", loadedStats.Sessions[0].Errors)
+	}
+}
+
+func TestJSONStore_Append(t *testing.T) {
+	store := &JSONStore{Path: filepath.Join(t.TempDir(), "stats.json")}
+	for _, wpm := range []float32{40, 50} {
+		if err := store.Append(types.TypingSession{WPM: wpm}); err != nil {
+			t.Fatalf("Append() unexpected error: %v", err)
+		}
+	}
+
+	stats, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(stats.Sessions) != 2 || stats.Sessions[1].WPM != 50 {
+		t.Errorf("Load() = %+v, want both appended sessions in order", stats.Sessions)
+	}
+}
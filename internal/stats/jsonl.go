@@ -0,0 +1,236 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"go-touch/internal/types"
+)
+
+// JSONLStore keeps one session per line and only appends after each session,
+// so a crash can at worst lose the line being written
+type JSONLStore struct {
+	Path string
+}
+
+// Load reads every session, upgraded to SchemaVersion. Blank lines and a
+// partially written last line are skipped; any other unreadable line is
+// reported as ErrCorrupt.
+func (s *JSONLStore) Load() (types.UserStats, error) {
+	var stats types.UserStats
+	err := WithLock(s.Path, func() error {
+		var err error
+		stats, _, _, err = s.read()
+		return err
+	})
+	return stats, err
+}
+
+// read loads the sessions upgraded to SchemaVersion. It also returns the
+// version the file was written at and whether the file has leftovers Compact
+// would remove.
+func (s *JSONLStore) read() (types.UserStats, int, bool, error) {
+	stats := types.UserStats{Version: SchemaVersion, Sessions: make([]types.TypingSession, 0)}
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return stats, SchemaVersion, false, nil
+	}
+	if err != nil {
+		return stats, 0, false, err
+	}
+
+	// Files without a header hold version 1 sessions
+	version := 1
+	dirty := false
+	lines := bytes.Split(data, []byte("\n"))
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			// The split leaves an empty element after the final newline
+			dirty = dirty || i < len(lines)-1
+			continue
+		}
+
+		if v, ok := parseHeader(line); i == 0 && ok {
+			version = v
+			continue
+		}
+
+		var session types.TypingSession
+		if err := json.Unmarshal(line, &session); err != nil {
+			if i == len(lines)-1 {
+				// An append cut short by a crash
+				dirty = true
+				continue
+			}
+			return stats, version, false, fmt.Errorf("%s line %d: %w: %v", s.Path, i+1, ErrCorrupt, err)
+		}
+		stats.Sessions = append(stats.Sessions, session)
+	}
+
+	if err := upgrade(&stats, version); err != nil {
+		return stats, version, false, fmt.Errorf("%s: %w", s.Path, err)
+	}
+	return stats, version, dirty, nil
+}
+
+// header returns the schema header line
+func header() []byte {
+	line, _ := json.Marshal(schemaHeader{SchemaVersion: SchemaVersion})
+	return append(line, '\n')
+}
+
+// Append writes the session as a new line
+func (s *JSONLStore) Append(session types.TypingSession) error {
+	line, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return WithLock(s.Path, func() error {
+		file, err := os.OpenFile(s.Path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		size := info.Size()
+
+		// Drop the remains of an append that was cut short
+		if size > 0 {
+			last := make([]byte, 1)
+			if _, err := file.ReadAt(last, size-1); err != nil && err != io.EOF {
+				return err
+			}
+			if last[0] != '\n' {
+				data, err := io.ReadAll(io.NewSectionReader(file, 0, size))
+				if err != nil {
+					return err
+				}
+				size = int64(bytes.LastIndexByte(data, '\n') + 1)
+				if err := file.Truncate(size); err != nil {
+					return err
+				}
+			}
+		}
+
+		if size == 0 {
+			line = append(header(), line...)
+		}
+
+		if _, err := file.Write(append(line, '\n')); err != nil {
+			return err
+		}
+		return file.Sync()
+	})
+}
+
+// Save atomically replaces the file with the given history, backing up the old one
+func (s *JSONLStore) Save(stats types.UserStats) error {
+	return WithLock(s.Path, func() error {
+		return s.write(stats)
+	})
+}
+
+// Update changes the history under one lock, rewriting the file when fn
+// reports a change
+func (s *JSONLStore) Update(fn func(stats *types.UserStats) bool) error {
+	return WithLock(s.Path, func() error {
+		stats, _, _, err := s.read()
+		if err != nil || !fn(&stats) {
+			return err
+		}
+		return s.write(stats)
+	})
+}
+
+// write replaces the file with the given history; the caller holds the lock
+func (s *JSONLStore) write(stats types.UserStats) error {
+	var buf bytes.Buffer
+	buf.Write(header())
+	for _, session := range stats.Sessions {
+		line, err := json.Marshal(session)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := rotateBackups(s.Path); err != nil {
+		return err
+	}
+	return WriteFileAtomic(s.Path, buf.Bytes(), 0644)
+}
+
+// Compact rewrites the file without blank lines or a partially written last
+// line, leaving a clean file untouched
+func (s *JSONLStore) Compact() error {
+	return WithLock(s.Path, func() error {
+		stats, _, dirty, err := s.read()
+		if err != nil || !dirty {
+			return err
+		}
+		return s.write(stats)
+	})
+}
+
+// Upgrade rewrites a file from an older schema version at SchemaVersion,
+// keeping the original as path.v<version>
+func (s *JSONLStore) Upgrade() error {
+	return WithLock(s.Path, func() error {
+		stats, version, _, err := s.read()
+		if err != nil || version == SchemaVersion {
+			return err
+		}
+
+		data, err := os.ReadFile(s.Path)
+		if err != nil {
+			return err
+		}
+		if err := WriteFileAtomic(fmt.Sprintf("%s.v%d", s.Path, version), data, 0644); err != nil {
+			return err
+		}
+		return s.write(stats)
+	})
+}
+
+// Backup adds the current file to the rotating backups
+func (s *JSONLStore) Backup() error {
+	return WithLock(s.Path, func() error {
+		return rotateBackups(s.Path)
+	})
+}
+
+// salvage reads every line it can and counts the ones it cannot. It also
+// returns the schema version of the file.
+func (s *JSONLStore) salvage(data []byte) ([]types.TypingSession, int, int) {
+	sessions := make([]types.TypingSession, 0)
+	version, dropped := 1, 0
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if v, ok := parseHeader(line); i == 0 && ok {
+			// A damaged header counts as the oldest version
+			if validVersion(v) {
+				version = v
+			} else {
+				dropped++
+			}
+			continue
+		}
+		var session types.TypingSession
+		if err := json.Unmarshal(line, &session); err != nil {
+			dropped++
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, version, dropped
+}
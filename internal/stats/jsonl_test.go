@@ -0,0 +1,136 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-touch/internal/types"
+)
+
+func TestJSONLStore_AppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user_stats.jsonl")
+	store := &JSONLStore{Path: path}
+
+	stats, err := store.Load()
+	if err != nil || stats.Sessions == nil || len(stats.Sessions) != 0 {
+		t.Fatalf("Load() of a missing file = %+v, %v, want empty sessions", stats, err)
+	}
+
+	for _, wpm := range []float32{40, 50, 60} {
+		if err := store.Append(types.TypingSession{WPM: wpm}); err != nil {
+			t.Fatalf("Append() unexpected error: %v", err)
+		}
+	}
+
+	data, _ := os.ReadFile(path)
+	if lines := strings.Count(string(data), "\n"); lines != 4 {
+		t.Errorf("file has %d lines, want a header and one per session", lines)
+	}
+
+	stats, err = store.Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(stats.Sessions) != 3 || stats.Sessions[2].WPM != 60 {
+		t.Errorf("Load() = %+v, want the three sessions in order", stats.Sessions)
+	}
+}
+
+func TestJSONLStore_PartialLastLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user_stats.jsonl")
+	// A crash cut the second append short
+	if err := os.WriteFile(path, []byte("{\"wpm\":40}\n{\"wpm\":5"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := &JSONLStore{Path: path}
+
+	stats, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(stats.Sessions) != 1 {
+		t.Fatalf("Load() Sessions length = %d, want 1", len(stats.Sessions))
+	}
+
+	// The next append replaces the partial line
+	if err := store.Append(types.TypingSession{WPM: 70}); err != nil {
+		t.Fatalf("Append() unexpected error: %v", err)
+	}
+	stats, err = store.Load()
+	if err != nil {
+		t.Fatalf("Load() after append unexpected error: %v", err)
+	}
+	if len(stats.Sessions) != 2 || stats.Sessions[1].WPM != 70 {
+		t.Errorf("Load() = %+v, want the partial line ignored", stats.Sessions)
+	}
+}
+
+func TestJSONLStore_CorruptLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user_stats.jsonl")
+	if err := os.WriteFile(path, []byte("{\"wpm\":40}\nnot json\n{\"wpm\":50}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := (&JSONLStore{Path: path}).Load()
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Load() error = %v, want an error naming line 2", err)
+	}
+}
+
+func TestJSONLStore_Compact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user_stats.jsonl")
+	if err := os.WriteFile(path, []byte("{\"wpm\":40}\n\n{\"wpm\":50}\n{\"wp"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := &JSONLStore{Path: path}
+
+	if err := store.Compact(); err != nil {
+		t.Fatalf("Compact() unexpected error: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if got := string(data); strings.Count(got, "\n") != 3 || strings.Contains(got, "\n\n") || !strings.HasSuffix(got, "}\n") {
+		t.Errorf("compacted file = %q, want a header and two clean lines", got)
+	}
+
+	// A clean file is left alone
+	info, _ := os.Stat(path)
+	if err := store.Compact(); err != nil {
+		t.Fatalf("Compact() of a clean file unexpected error: %v", err)
+	}
+	if after, _ := os.Stat(path); !after.ModTime().Equal(info.ModTime()) {
+		t.Errorf("Compact() rewrote a clean file")
+	}
+}
+
+func TestJSONLStore_Update(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user_stats.jsonl")
+	store := &JSONLStore{Path: path}
+	if err := store.Append(types.TypingSession{WPM: 40}); err != nil {
+		t.Fatal(err)
+	}
+
+	// fn sees the stored history, including sessions appended since it was loaded
+	err := store.Update(func(stats *types.UserStats) bool {
+		stats.Sessions = append(stats.Sessions, types.TypingSession{WPM: 50})
+		return true
+	})
+	if err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	stats, _ := store.Load()
+	if len(stats.Sessions) != 2 || stats.Sessions[1].WPM != 50 {
+		t.Errorf("Load() after Update() = %+v, want both sessions", stats.Sessions)
+	}
+
+	// An unchanged history is not rewritten
+	before, _ := os.ReadFile(path)
+	err = store.Update(func(stats *types.UserStats) bool {
+		stats.Sessions = nil
+		return false
+	})
+	if after, _ := os.ReadFile(path); err != nil || string(after) != string(before) {
+		t.Errorf("Update() without a change = %v, rewrote the file", err)
+	}
+}
@@ -0,0 +1,24 @@
+package stats
+
+import (
+	"fmt"
+	"os"
+)
+
+// WithLock runs fn while holding an exclusive lock on path.lock, so instances
+// running side by side take turns with the history and the files kept next to
+// it instead of overwriting them
+func WithLock(path string, fn func() error) error {
+	file, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := lockFile(file); err != nil {
+		return fmt.Errorf("lock %s: %w", path, err)
+	}
+	defer unlockFile(file)
+
+	return fn()
+}
@@ -0,0 +1,261 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"go-touch/internal/types"
+)
+
+func TestJSONLStore_ConcurrentAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user_stats.jsonl")
+
+	// Two instances appending side by side, each with its own store
+	var wg sync.WaitGroup
+	for instance := 0; instance < 2; instance++ {
+		wg.Add(1)
+		go func(instance int) {
+			defer wg.Done()
+			store := &JSONLStore{Path: path}
+			for i := 0; i < 25; i++ {
+				if err := store.Append(types.TypingSession{WPM: float32(instance*100 + i)}); err != nil {
+					t.Errorf("Append() unexpected error: %v", err)
+				}
+			}
+		}(instance)
+	}
+	wg.Wait()
+
+	stats, err := (&JSONLStore{Path: path}).Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(stats.Sessions) != 50 {
+		t.Errorf("Load() has %d sessions, want all 50", len(stats.Sessions))
+	}
+}
+
+func TestJSONStore_ConcurrentAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user_stats.json")
+
+	var wg sync.WaitGroup
+	for instance := 0; instance < 2; instance++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store := &JSONStore{Path: path}
+			for i := 0; i < 10; i++ {
+				if err := store.Append(types.TypingSession{WPM: 50}); err != nil {
+					t.Errorf("Append() unexpected error: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats, err := (&JSONStore{Path: path}).Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(stats.Sessions) != 20 {
+		t.Errorf("Load() has %d sessions, want all 20", len(stats.Sessions))
+	}
+}
+
+func TestRotateBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user_stats.jsonl")
+
+	// Nothing to back up yet
+	if err := rotateBackups(path); err != nil {
+		t.Fatalf("rotateBackups() of a missing file unexpected error: %v", err)
+	}
+	if _, err := os.Stat(backupName(path, 1)); !os.IsNotExist(err) {
+		t.Error("rotateBackups() backed up a missing file")
+	}
+
+	for i := 1; i <= maxBackups+1; i++ {
+		if err := os.WriteFile(path, []byte(fmt.Sprintf("version %d", i)), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := rotateBackups(path); err != nil {
+			t.Fatalf("rotateBackups() unexpected error: %v", err)
+		}
+	}
+
+	for n := 1; n <= maxBackups; n++ {
+		data, err := os.ReadFile(backupName(path, n))
+		if want := fmt.Sprintf("version %d", maxBackups+2-n); err != nil || string(data) != want {
+			t.Errorf("backup %d = %q, %v, want %q", n, data, err, want)
+		}
+	}
+	if _, err := os.Stat(backupName(path, maxBackups+1)); !os.IsNotExist(err) {
+		t.Errorf("rotateBackups() kept more than %d backups", maxBackups)
+	}
+}
+
+func TestLoad_ErrCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	jsonl := filepath.Join(dir, "user_stats.jsonl")
+	if err := os.WriteFile(jsonl, []byte("garbage\n{\"wpm\":40}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&JSONLStore{Path: jsonl}).Load(); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("JSONLStore.Load() error = %v, want ErrCorrupt", err)
+	}
+
+	legacy := filepath.Join(dir, "legacy.json")
+	if err := os.WriteFile(legacy, []byte(`{"sessions": [{"wpm": 4`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&JSONStore{Path: legacy}).Load(); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("JSONStore.Load() error = %v, want ErrCorrupt", err)
+	}
+	if _, err := Open(legacy); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Open() of a damaged legacy file error = %v, want ErrCorrupt", err)
+	}
+}
+
+func TestRecover_JSONL(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "user_stats.json")
+	jsonl := filepath.Join(dir, "user_stats.jsonl")
+	damaged := "{\"wpm\":40}\n\x00\x00\x00\n{\"wpm\":50}\n{\"wpm\":\n{\"wpm\":60}\n"
+	if err := os.WriteFile(jsonl, []byte(damaged), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	recovery, err := Recover(path)
+	if err != nil {
+		t.Fatalf("Recover() unexpected error: %v", err)
+	}
+	if recovery.Path != jsonl || recovery.Salvaged != 3 || recovery.Dropped != 2 {
+		t.Errorf("Recover() = %+v, want 3 sessions salvaged and 2 dropped from %s", recovery, jsonl)
+	}
+
+	// The damaged file is quarantined untouched
+	data, err := os.ReadFile(recovery.Quarantine)
+	if err != nil || string(data) != damaged {
+		t.Errorf("quarantined file = %q, %v, want the damaged contents", data, err)
+	}
+	if !strings.Contains(recovery.String(), recovery.Quarantine) {
+		t.Errorf("Recovery.String() = %q, want it to name the quarantined file", recovery.String())
+	}
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() after recovery unexpected error: %v", err)
+	}
+	stats, err := store.Load()
+	if err != nil || len(stats.Sessions) != 3 || stats.Sessions[2].WPM != 60 {
+		t.Errorf("Load() after recovery = %+v, %v, want the salvaged sessions", stats.Sessions, err)
+	}
+}
+
+func TestRecover_LegacyJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "user_stats.json")
+	// Cut off in the middle of the third session
+	truncated := `{"sessions": [{"wpm": 40}, {"wpm": 50}, {"wpm": 6`
+	if err := os.WriteFile(path, []byte(truncated), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	recovery, err := Recover(path)
+	if err != nil {
+		t.Fatalf("Recover() unexpected error: %v", err)
+	}
+	if recovery.Path != path || recovery.Salvaged != 2 || recovery.Dropped != 1 {
+		t.Errorf("Recover() = %+v, want 2 sessions salvaged and 1 dropped from %s", recovery, path)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("damaged legacy file still in place after recovery")
+	}
+
+	// The salvaged sessions land in the JSONL store, so no migration is attempted
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() after recovery unexpected error: %v", err)
+	}
+	stats, err := store.Load()
+	if err != nil || len(stats.Sessions) != 2 {
+		t.Errorf("Load() after recovery = %+v, %v, want 2 sessions", stats.Sessions, err)
+	}
+}
+
+func TestJSONStore_Salvage(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		wantCount   int
+		wantDropped int
+	}{
+		{"intact", `{"sessions": [{"wpm": 40}, {"wpm": 50}]}`, 2, 0},
+		{"other keys first", `{"version": 1, "sessions": [{"wpm": 40}]}`, 1, 0},
+		{"truncated", `{"sessions": [{"wpm": 40}, {"wp`, 1, 1},
+		{"not an object", `[1, 2`, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions, _, dropped := (&JSONStore{}).salvage([]byte(tt.data))
+			if len(sessions) != tt.wantCount || dropped != tt.wantDropped {
+				t.Errorf("salvage() = %d sessions, %d dropped, want %d, %d", len(sessions), dropped, tt.wantCount, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestOpenHistory_RecoversDamagedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user_stats.json")
+	if err := os.WriteFile(path, []byte(`{"sessions": [{"wpm": 40}, {"wpm": 5`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, history, warnings, err := OpenHistory(path)
+	if err != nil {
+		t.Fatalf("OpenHistory() unexpected error: %v", err)
+	}
+	if store == nil || len(history.Sessions) != 1 {
+		t.Errorf("OpenHistory() history = %+v, want the one readable session", history.Sessions)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "recovered 1 sessions") {
+		t.Errorf("OpenHistory() warnings = %q, want them to report the recovery", warnings)
+	}
+
+	// The next start is clean
+	if _, _, warnings, err := OpenHistory(path); err != nil || len(warnings) != 0 {
+		t.Errorf("second OpenHistory() = %q, %v, want no warnings", warnings, err)
+	}
+}
+
+func TestOpenHistory_KeepsOlderBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user_stats.jsonl")
+	for i := 1; i <= 2; i++ {
+		store, _, _, err := OpenHistory(path)
+		if err != nil {
+			t.Fatalf("OpenHistory() unexpected error: %v", err)
+		}
+		if err := store.Append(types.TypingSession{WPM: float32(40 + i)}); err != nil {
+			t.Fatalf("Append() unexpected error: %v", err)
+		}
+	}
+
+	// Opening an unchanged history again must not push the older copies out
+	for i := 0; i < maxBackups; i++ {
+		if _, _, _, err := OpenHistory(path); err != nil {
+			t.Fatalf("OpenHistory() unexpected error: %v", err)
+		}
+	}
+
+	older, err := (&JSONLStore{Path: backupName(path, 2)}).Load()
+	if err != nil || len(older.Sessions) != 1 {
+		t.Errorf("backup 2 = %+v, %v, want the history before the second session", older.Sessions, err)
+	}
+	if _, err := os.Stat(backupName(path, 3)); !os.IsNotExist(err) {
+		t.Errorf("repeated opens of an unchanged history created backup 3: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go-touch/internal/types"
+)
+
+// SchemaVersion is the history format written by this build:
+//
+//	1: a single JSON document {"sessions": [...]}
+//	3: one JSON session per line after a {"schema_version": 3} header line,
+//	   every session recording its mode; JSON documents carry "version": 3
+//
+// Version 2 was never released. JSONL files without a header come from
+// development builds that preceded it and are read as version 1.
+const SchemaVersion = 3
+
+// ErrUnsupportedVersion marks a history written by a newer build, which is left untouched
+var ErrUnsupportedVersion = errors.New("stats file was written by a newer version of GoTouch")
+
+// upgradeV1 brings a version 1 session up to SchemaVersion. Sessions saved
+// before modes existed were timed. Net WPM is left unset on sessions saved
+// before it was measured: their WPM was the whole target text length over the
+// time taken, not net WPM.
+func upgradeV1(s *types.TypingSession) {
+	s.Mode = s.SessionMode()
+}
+
+// validVersion reports whether a history may claim version: one this build
+// upgrades from or writes, or a newer one it refuses to touch
+func validVersion(version int) bool {
+	return version == 1 || version >= SchemaVersion
+}
+
+// schemaHeader is the first line of a JSONL history from version 3 on
+type schemaHeader struct {
+	SchemaVersion int `json:"schema_version"`
+}
+
+// parseHeader returns the version of a schema header line, false when the
+// line is not a header
+func parseHeader(line []byte) (int, bool) {
+	var header struct {
+		SchemaVersion *int `json:"schema_version"`
+	}
+	if json.Unmarshal(line, &header) != nil || header.SchemaVersion == nil {
+		return 0, false
+	}
+	return *header.SchemaVersion, true
+}
+
+// upgrade brings the sessions of a history at version up to SchemaVersion in place
+func upgrade(stats *types.UserStats, version int) error {
+	if !validVersion(version) {
+		return fmt.Errorf("%w: invalid schema version %d", ErrCorrupt, version)
+	}
+	if version > SchemaVersion {
+		return fmt.Errorf("%w: schema version %d, this build reads up to %d", ErrUnsupportedVersion, version, SchemaVersion)
+	}
+	if version == 1 {
+		for i := range stats.Sessions {
+			upgradeV1(&stats.Sessions[i])
+		}
+	}
+	stats.Version = SchemaVersion
+	return nil
+}
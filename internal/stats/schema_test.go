@@ -0,0 +1,197 @@
+package stats
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-touch/internal/types"
+)
+
+// copyFixture copies testdata/name into a temporary directory as target
+func copyFixture(t *testing.T, name, target string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), target)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("copy fixture: %v", err)
+	}
+	return path
+}
+
+func TestOpen_Fixtures(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		target   string
+		sessions int
+		backup   string // Pre-migration copy of the fixture, next to it
+	}{
+		{"v1_user_stats.json", "user_stats.json", 2, "user_stats.json.migrated"},
+		{"v3_user_stats.jsonl", "user_stats.jsonl", 2, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			path := copyFixture(t, tt.fixture, tt.target)
+			original, _ := os.ReadFile(path)
+
+			store, err := Open(path)
+			if err != nil {
+				t.Fatalf("Open() unexpected error: %v", err)
+			}
+			stats, err := store.Load()
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+
+			if stats.Version != SchemaVersion {
+				t.Errorf("Load() Version = %d, want %d", stats.Version, SchemaVersion)
+			}
+			if len(stats.Sessions) != tt.sessions {
+				t.Fatalf("Load() has %d sessions, want %d", len(stats.Sessions), tt.sessions)
+			}
+			for i, session := range stats.Sessions {
+				// Sessions from before gross and net WPM were measured keep net WPM unset
+				var wantNet float32
+				if session.GrossWPM > 0 {
+					wantNet = session.WPM
+				}
+				if session.Mode == "" || session.NetWPM != wantNet {
+					t.Errorf("session %d = mode %q, net WPM %v, want an explicit mode and net WPM %v", i, session.Mode, session.NetWPM, wantNet)
+				}
+			}
+			if stats.Sessions[0].Mode != types.ModeTime || stats.Sessions[1].Mode != types.ModeWords {
+				t.Errorf("Load() modes = %q, %q, want time for the session without a mode", stats.Sessions[0].Mode, stats.Sessions[1].Mode)
+			}
+			if stats.Sessions[0].Accuracy != 93.1 || stats.Sessions[1].Consistency != 78.5 {
+				t.Errorf("Load() lost fields: %+v", stats.Sessions[:2])
+			}
+
+			// The store now carries the current header
+			jsonl := store.(*JSONLStore).Path
+			data, _ := os.ReadFile(jsonl)
+			if !strings.HasPrefix(string(data), `{"schema_version":3}`+"\n") {
+				t.Errorf("upgraded file starts %q, want the schema header", strings.SplitN(string(data), "\n", 2)[0])
+			}
+
+			if tt.backup == "" {
+				if string(data) != string(original) {
+					t.Error("Open() rewrote a current file")
+				}
+				return
+			}
+			backup, err := os.ReadFile(filepath.Join(filepath.Dir(path), tt.backup))
+			if err != nil || string(backup) != string(original) {
+				t.Errorf("pre-migration backup %s = %v, want the original file", tt.backup, err)
+			}
+		})
+	}
+}
+
+func TestOpen_HeaderlessJSONL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user_stats.jsonl")
+	if err := os.WriteFile(path, []byte("{\"wpm\":40}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() unexpected error: %v", err)
+	}
+	stats, err := store.Load()
+	if err != nil || len(stats.Sessions) != 1 || stats.Sessions[0].Mode != types.ModeTime {
+		t.Errorf("Load() = %+v, %v, want the session upgraded from version 1", stats.Sessions, err)
+	}
+	if data, _ := os.ReadFile(path); !strings.HasPrefix(string(data), `{"schema_version":3}`) {
+		t.Errorf("Open() left %q, want the file rewritten with a header", data)
+	}
+}
+
+func TestOpen_NewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user_stats.jsonl")
+	newer := "{\"schema_version\":99}\n{\"wpm\":40}\n"
+	if err := os.WriteFile(path, []byte(newer), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Open(path); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Open() error = %v, want ErrUnsupportedVersion", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != newer {
+		t.Error("Open() changed a file from a newer version")
+	}
+}
+
+func TestOpenHistory_InvalidSchemaVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		content string
+	}{
+		{"json version 0", "user_stats.json", `{"version": 0, "sessions": [{"wpm": 40}]}`},
+		{"json negative version", "user_stats.json", `{"version": -2, "sessions": [{"wpm": 40}]}`},
+		{"jsonl header 0", "user_stats.jsonl", "{\"schema_version\":0}\n{\"wpm\":40}\n"},
+		{"jsonl negative header", "user_stats.jsonl", "{\"schema_version\":-1}\n{\"wpm\":40}\n"},
+		{"jsonl unreleased header 2", "user_stats.jsonl", "{\"schema_version\":2}\n{\"wpm\":40}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.target)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			// The file is treated as damaged and its sessions salvaged
+			_, history, warnings, err := OpenHistory(path)
+			if err != nil {
+				t.Fatalf("OpenHistory() unexpected error: %v", err)
+			}
+			if len(history.Sessions) != 1 || history.Sessions[0].WPM != 40 || len(warnings) == 0 {
+				t.Errorf("OpenHistory() = %+v, %q, want the session recovered", history.Sessions, warnings)
+			}
+		})
+	}
+}
+
+func TestJSONLStore_AppendWritesHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user_stats.jsonl")
+	store := &JSONLStore{Path: path}
+	if err := store.Append(types.TypingSession{WPM: 40, Mode: types.ModeTime, NetWPM: 40}); err != nil {
+		t.Fatalf("Append() unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || lines[0] != `{"schema_version":3}` {
+		t.Errorf("file = %q, want the schema header and the session", data)
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	stats := types.UserStats{Sessions: []types.TypingSession{
+		{WPM: 40},
+		{WPM: 50, Mode: types.ModeZen, NetWPM: 48},
+	}}
+	if err := upgrade(&stats, 1); err != nil {
+		t.Fatalf("upgrade() unexpected error: %v", err)
+	}
+
+	if stats.Version != SchemaVersion {
+		t.Errorf("upgrade() Version = %d, want %d", stats.Version, SchemaVersion)
+	}
+	if s := stats.Sessions[0]; s.Mode != types.ModeTime || s.NetWPM != 0 {
+		t.Errorf("upgrade() first session = %+v, want time mode and no net WPM", s)
+	}
+	if s := stats.Sessions[1]; s.Mode != types.ModeZen || s.NetWPM != 48 {
+		t.Errorf("upgrade() second session = %+v, want its own mode and net WPM kept", s)
+	}
+	for _, version := range []int{0, -1, 2} {
+		if err := upgrade(&types.UserStats{}, version); !errors.Is(err, ErrCorrupt) {
+			t.Errorf("upgrade(%d) error = %v, want ErrCorrupt", version, err)
+		}
+	}
+}
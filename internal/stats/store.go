@@ -0,0 +1,209 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+// StatsStore persists the typing history
+type StatsStore interface {
+	// Load reads every recorded session
+	Load() (types.UserStats, error)
+	// Append records one finished session
+	Append(session types.TypingSession) error
+	// Save replaces the whole history
+	Save(stats types.UserStats) error
+	// Update reads the history and, when fn reports a change, writes it back,
+	// holding the lock throughout so no session appended meanwhile is lost
+	Update(fn func(stats *types.UserStats) bool) error
+	// Compact rewrites the storage without leftovers such as partially written records
+	Compact() error
+	// Backup adds the current history to the rotating backups
+	Backup() error
+}
+
+// ErrCorrupt marks a history file that cannot be read; Recover salvages what it can
+var ErrCorrupt = errors.New("stats file is damaged")
+
+// errNoPath rejects an empty stats path, which would put the history in the working directory
+var errNoPath = errors.New("no stats file configured")
+
+// Suffix kept on a legacy JSON history after it has been migrated
+const migratedSuffix = ".migrated"
+
+// Open returns the store for the configured stats path. A .jsonl path is used
+// as is. For a .json path the history lives in a .jsonl file next to it, and an
+// existing JSON history is migrated into it on first open. Histories from older
+// schema versions are upgraded.
+func Open(path string) (StatsStore, error) {
+	if path == "" {
+		return nil, errNoPath
+	}
+	store := &JSONLStore{Path: jsonlPath(path)}
+	if store.Path != path {
+		if err := migrate(&JSONStore{Path: path}, store); err != nil {
+			return nil, fmt.Errorf("migrate %s: %w", path, err)
+		}
+	}
+	if err := store.Upgrade(); err != nil {
+		return nil, fmt.Errorf("upgrade %s: %w", store.Path, err)
+	}
+	return store, nil
+}
+
+// jsonlPath returns the JSONL history for the configured stats path
+func jsonlPath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".jsonl"
+}
+
+// migrate copies a legacy JSON history into an empty JSONL store and keeps the
+// original file renamed with migratedSuffix
+func migrate(from *JSONStore, to *JSONLStore) error {
+	return WithLock(to.Path, func() error {
+		if _, err := os.Stat(to.Path); err == nil {
+			return nil
+		}
+		if _, err := os.Stat(from.Path); os.IsNotExist(err) {
+			return nil
+		}
+
+		stats, err := from.read()
+		if err != nil {
+			return err
+		}
+		if err := to.write(stats); err != nil {
+			return err
+		}
+		return os.Rename(from.Path, from.Path+migratedSuffix)
+	})
+}
+
+// Recovery describes what Recover did with a damaged history
+type Recovery struct {
+	Path       string // Damaged history file
+	Salvaged   int    // Sessions recovered into the store
+	Dropped    int    // Unreadable records left out
+	Quarantine string // Where the damaged file was moved
+}
+
+func (r Recovery) String() string {
+	return fmt.Sprintf("%s was damaged: recovered %d sessions, dropped %d unreadable records. The damaged file was kept as %s",
+		r.Path, r.Salvaged, r.Dropped, r.Quarantine)
+}
+
+// Recover salvages every readable session from the damaged history at the
+// configured stats path, moves the damaged file aside and writes the salvaged
+// sessions to the store Open uses
+func Recover(path string) (Recovery, error) {
+	store := &JSONLStore{Path: jsonlPath(path)}
+	var recovery Recovery
+	err := WithLock(store.Path, func() error {
+		damaged, _, salvage := historyFile(path)
+		data, err := os.ReadFile(damaged)
+		if err != nil {
+			return err
+		}
+		sessions, version, dropped := salvage(data)
+		stats := types.UserStats{Sessions: sessions}
+		if err := upgrade(&stats, version); err != nil {
+			return err
+		}
+
+		recovery = Recovery{
+			Path:       damaged,
+			Salvaged:   len(sessions),
+			Dropped:    dropped,
+			Quarantine: fmt.Sprintf("%s.corrupt-%s", damaged, time.Now().Format("20060102-150405")),
+		}
+		if err := os.Rename(damaged, recovery.Quarantine); err != nil {
+			return err
+		}
+		return store.write(stats)
+	})
+	return recovery, err
+}
+
+// historyFile returns the file holding the history for the configured stats
+// path with functions to read and salvage it. A legacy JSON history is used
+// while it has not been migrated.
+func historyFile(path string) (string, func() (types.UserStats, error), func([]byte) ([]types.TypingSession, int, int)) {
+	store := &JSONLStore{Path: jsonlPath(path)}
+	if _, err := os.Stat(store.Path); os.IsNotExist(err) && store.Path != path {
+		legacy := &JSONStore{Path: path}
+		return legacy.Path, legacy.read, legacy.salvage
+	}
+	read := func() (types.UserStats, error) {
+		stats, _, _, err := store.read()
+		return stats, err
+	}
+	return store.Path, read, store.salvage
+}
+
+// ReadHistory loads the history without changing anything on disk, for
+// commands that only look at it. A legacy history is read where it is, older
+// schema versions are upgraded in memory only and a damaged file is salvaged
+// without being moved aside. The warnings are for the user.
+func ReadHistory(path string) (types.UserStats, []string, error) {
+	if path == "" {
+		return types.UserStats{}, nil, errNoPath
+	}
+	file, read, salvage := historyFile(path)
+	history, err := read()
+	if !errors.Is(err, ErrCorrupt) {
+		return history, nil, err
+	}
+
+	data, readErr := os.ReadFile(file)
+	if readErr != nil {
+		return history, nil, readErr
+	}
+	sessions, version, dropped := salvage(data)
+	history = types.UserStats{Sessions: sessions}
+	if err := upgrade(&history, version); err != nil {
+		return history, nil, fmt.Errorf("%s: %w", file, err)
+	}
+	warning := fmt.Sprintf("%s is damaged: showing %d readable sessions, skipped %d unreadable records. Start a session to recover it",
+		file, len(sessions), dropped)
+	return history, []string{warning}, nil
+}
+
+// OpenHistory opens the stats store and loads the history, salvaging a damaged
+// file. It also compacts and backs up the history. The warnings describe a
+// recovery or a failed step for the user.
+func OpenHistory(path string) (StatsStore, types.UserStats, []string, error) {
+	var warnings []string
+	store, err := Open(path)
+	var history types.UserStats
+	if err == nil {
+		history, err = store.Load()
+	}
+	if errors.Is(err, ErrCorrupt) {
+		recovery, recoverErr := Recover(path)
+		if recoverErr != nil {
+			return nil, history, nil, fmt.Errorf("%w (recovery failed: %v)", err, recoverErr)
+		}
+		warnings = append(warnings, recovery.String())
+
+		store, err = Open(path)
+		if err == nil {
+			history, err = store.Load()
+		}
+	}
+	if err != nil {
+		return nil, history, warnings, err
+	}
+
+	if err := store.Compact(); err != nil {
+		warnings = append(warnings, fmt.Sprintf("Failed to compact stats: %v", err))
+	}
+	if err := store.Backup(); err != nil {
+		warnings = append(warnings, fmt.Sprintf("Failed to back up stats: %v", err))
+	}
+	return store, history, warnings, nil
+}
@@ -0,0 +1,176 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-touch/internal/types"
+)
+
+func TestOpen_MigratesJSON(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, "user_stats.json")
+	old := types.UserStats{Sessions: []types.TypingSession{{WPM: 40}, {WPM: 55}}}
+	if err := (&JSONStore{Path: legacy}).Save(old); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := Open(legacy)
+	if err != nil {
+		t.Fatalf("Open() unexpected error: %v", err)
+	}
+	if got := store.(*JSONLStore).Path; got != filepath.Join(dir, "user_stats.jsonl") {
+		t.Errorf("Open() store path = %q, want user_stats.jsonl next to the JSON file", got)
+	}
+
+	stats, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(stats.Sessions) != 2 || stats.Sessions[1].WPM != 55 {
+		t.Errorf("Load() after migration = %+v, want the legacy sessions", stats.Sessions)
+	}
+
+	// The legacy file is kept aside rather than deleted
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Errorf("legacy file still in place after migration")
+	}
+	if _, err := os.Stat(legacy + migratedSuffix); err != nil {
+		t.Errorf("legacy file not kept as %s: %v", legacy+migratedSuffix, err)
+	}
+
+	// Opening again uses the migrated history
+	store, err = Open(legacy)
+	if err != nil {
+		t.Fatalf("second Open() unexpected error: %v", err)
+	}
+	if stats, _ := store.Load(); len(stats.Sessions) != 2 {
+		t.Errorf("Load() after reopening has %d sessions, want 2", len(stats.Sessions))
+	}
+}
+
+func TestOpen_BrokenJSONIsNotMigrated(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, "user_stats.json")
+	if err := os.WriteFile(legacy, []byte(`{"sessions": [`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Open(legacy); err == nil {
+		t.Error("Open() with a broken legacy file succeeded, want an error")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "user_stats.jsonl")); !os.IsNotExist(err) {
+		t.Error("Open() created a JSONL file from a broken legacy file")
+	}
+}
+
+func TestOpen_JSONLPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() unexpected error: %v", err)
+	}
+	if got := store.(*JSONLStore).Path; got != path {
+		t.Errorf("Open() store path = %q, want %q", got, path)
+	}
+}
+
+func TestOpen_EmptyPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	if _, err := Open(""); err == nil {
+		t.Error("Open(\"\") succeeded, want an error")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Open(\"\") wrote %d files to the working directory", len(entries))
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.json")
+	if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFileAtomic(path, []byte("new"), 0644); err != nil {
+		t.Fatalf("WriteFileAtomic() unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "new" {
+		t.Errorf("file = %q, want %q", data, "new")
+	}
+
+	// No temporary files are left behind
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the file", len(entries))
+	}
+}
+
+// dirContents maps every file in dir to its contents
+func dirContents(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents := make(map[string]string)
+	for _, entry := range entries {
+		data, _ := os.ReadFile(filepath.Join(dir, entry.Name()))
+		contents[entry.Name()] = string(data)
+	}
+	return contents
+}
+
+func TestReadHistory_LeavesFilesAlone(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		target   string
+		sessions int
+		warnings int
+	}{
+		{"v1_user_stats.json", "user_stats.json", 2, 0},
+		{"v3_user_stats.jsonl", "user_stats.jsonl", 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			path := copyFixture(t, tt.fixture, tt.target)
+			before := dirContents(t, filepath.Dir(path))
+
+			history, warnings, err := ReadHistory(path)
+			if err != nil {
+				t.Fatalf("ReadHistory() unexpected error: %v", err)
+			}
+			if history.Version != SchemaVersion || len(history.Sessions) != tt.sessions || len(warnings) != tt.warnings {
+				t.Errorf("ReadHistory() = version %d, %d sessions, %q, want version %d, %d sessions",
+					history.Version, len(history.Sessions), warnings, SchemaVersion, tt.sessions)
+			}
+			if after := dirContents(t, filepath.Dir(path)); !reflect.DeepEqual(after, before) {
+				t.Errorf("ReadHistory() changed the stats directory: %q, want %q", after, before)
+			}
+		})
+	}
+
+	t.Run("damaged", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "user_stats.jsonl")
+		if err := os.WriteFile(path, []byte("{\"wpm\":40}\ngarbage\n{\"wpm\":50}\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		before := dirContents(t, filepath.Dir(path))
+
+		history, warnings, err := ReadHistory(path)
+		if err != nil {
+			t.Fatalf("ReadHistory() unexpected error: %v", err)
+		}
+		if len(history.Sessions) != 2 || len(warnings) != 1 || !strings.Contains(warnings[0], "skipped 1 unreadable") {
+			t.Errorf("ReadHistory() = %d sessions, %q, want the 2 readable sessions and a warning", len(history.Sessions), warnings)
+		}
+		if after := dirContents(t, filepath.Dir(path)); !reflect.DeepEqual(after, before) {
+			t.Errorf("ReadHistory() changed the stats directory: %q, want %q", after, before)
+		}
+	})
+}
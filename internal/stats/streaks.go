@@ -0,0 +1,58 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+// PracticeDays returns the typing time of every local calendar day with at
+// least one session, keyed by the day's midnight
+func PracticeDays(history types.UserStats) map[time.Time]time.Duration {
+	days := make(map[time.Time]time.Duration)
+	for _, session := range history.Sessions {
+		day := PeriodDay.Start(session.Date.Local())
+		days[day] += session.Duration
+	}
+	return days
+}
+
+// Streaks returns the current and longest runs of consecutive practice days.
+// The current streak runs up to today, or up to yesterday while today has not
+// been practised yet, so it only breaks once a whole day is missed.
+func Streaks(history types.UserStats, now time.Time) (current, longest int) {
+	days := PracticeDays(history)
+	sorted := make([]time.Time, 0, len(days))
+	for day := range days {
+		sorted = append(sorted, day)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	run := 0
+	for i, day := range sorted {
+		if i > 0 && sorted[i-1].AddDate(0, 0, 1).Equal(day) {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+
+	today := PeriodDay.Start(now.Local())
+	day := today
+	if !hasDay(days, today) {
+		day = today.AddDate(0, 0, -1)
+	}
+	for hasDay(days, day) {
+		current++
+		day = day.AddDate(0, 0, -1)
+	}
+	return current, longest
+}
+
+// hasDay reports whether the day had a session, even one of zero length
+func hasDay(days map[time.Time]time.Duration, day time.Time) bool {
+	_, ok := days[day]
+	return ok
+}
@@ -0,0 +1,56 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+func TestPracticeDays(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2025, 5, d, hour, 0, 0, 0, time.Local) }
+	history := types.UserStats{Sessions: []types.TypingSession{
+		{Date: day(12, 9), Duration: time.Minute},
+		{Date: day(12, 21), Duration: 2 * time.Minute},
+		{Date: day(14, 8), Duration: 30 * time.Second},
+	}}
+
+	days := PracticeDays(history)
+	if len(days) != 2 || days[day(12, 0)] != 3*time.Minute || days[day(14, 0)] != 30*time.Second {
+		t.Errorf("PracticeDays() = %v, want 3m on the 12th and 30s on the 14th", days)
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	now := time.Date(2025, 5, 20, 18, 0, 0, 0, time.Local)
+	daysAgo := func(days ...int) types.UserStats {
+		var history types.UserStats
+		for _, d := range days {
+			history.Sessions = append(history.Sessions, types.TypingSession{Date: now.AddDate(0, 0, -d), Duration: time.Minute})
+		}
+		return history
+	}
+
+	tests := []struct {
+		name        string
+		history     types.UserStats
+		wantCurrent int
+		wantLongest int
+	}{
+		{"empty", types.UserStats{}, 0, 0},
+		{"today only", daysAgo(0), 1, 1},
+		{"running to today", daysAgo(2, 1, 0), 3, 3},
+		{"alive until today is over", daysAgo(3, 2, 1), 3, 3},
+		{"broken by a missed day", daysAgo(5, 4, 3, 2), 0, 4},
+		{"longest in the past", daysAgo(20, 19, 18, 17, 1, 0), 2, 4},
+		{"several sessions a day", daysAgo(1, 1, 0, 0), 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, longest := Streaks(tt.history, now)
+			if current != tt.wantCurrent || longest != tt.wantLongest {
+				t.Errorf("Streaks() = (%d, %d), want (%d, %d)", current, longest, tt.wantCurrent, tt.wantLongest)
+			}
+		})
+	}
+}
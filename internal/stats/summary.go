@@ -0,0 +1,94 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+// Period is the span sessions are grouped by in a summary
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week" // Weeks start on Monday
+	PeriodMonth Period = "month"
+)
+
+// ParsePeriod checks a period name given on the command line
+func ParsePeriod(name string) (Period, error) {
+	switch period := Period(name); period {
+	case PeriodDay, PeriodWeek, PeriodMonth:
+		return period, nil
+	}
+	return "", fmt.Errorf("unknown period %q, want day, week or month", name)
+}
+
+// Start returns the beginning of the period containing t, in t's location
+func (p Period) Start(t time.Time) time.Time {
+	year, month, day := t.Date()
+	switch p {
+	case PeriodWeek:
+		offset := (int(t.Weekday()) + 6) % 7 // Days since Monday
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+	case PeriodMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// Summary aggregates a group of sessions
+type Summary struct {
+	Start       time.Time     `json:"start"` // Beginning of the period, zero for an ungrouped summary
+	Sessions    int           `json:"sessions"`
+	AvgWPM      float32       `json:"avg_wpm"`
+	BestWPM     float32       `json:"best_wpm"`
+	AvgAccuracy float32       `json:"avg_accuracy"`
+	Errors      int           `json:"errors"`
+	Duration    time.Duration `json:"duration"` // Total typing time
+}
+
+// Summarize aggregates sessions into one summary
+func Summarize(sessions []types.TypingSession) Summary {
+	var summary Summary
+	var totalWPM, totalAccuracy float32
+	for _, session := range sessions {
+		summary.Sessions++
+		totalWPM += session.WPM
+		totalAccuracy += session.Accuracy
+		summary.Errors += session.Errors
+		summary.Duration += session.Duration
+		if session.WPM > summary.BestWPM {
+			summary.BestWPM = session.WPM
+		}
+	}
+	if summary.Sessions > 0 {
+		summary.AvgWPM = totalWPM / float32(summary.Sessions)
+		summary.AvgAccuracy = totalAccuracy / float32(summary.Sessions)
+	}
+	return summary
+}
+
+// Aggregate groups the sessions by period, oldest first. Periods without
+// sessions are left out.
+func Aggregate(sessions []types.TypingSession, period Period) []Summary {
+	groups := make(map[time.Time][]types.TypingSession)
+	for _, session := range sessions {
+		start := period.Start(session.Date.Local())
+		groups[start] = append(groups[start], session)
+	}
+
+	summaries := make([]Summary, 0, len(groups))
+	for start, group := range groups {
+		summary := Summarize(group)
+		summary.Start = start
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Start.Before(summaries[j].Start)
+	})
+	return summaries
+}
@@ -0,0 +1,93 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+func TestPeriod_Start(t *testing.T) {
+	// A Wednesday afternoon
+	at := time.Date(2025, 5, 14, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		period Period
+		want   time.Time
+	}{
+		{PeriodDay, time.Date(2025, 5, 14, 0, 0, 0, 0, time.UTC)},
+		{PeriodWeek, time.Date(2025, 5, 12, 0, 0, 0, 0, time.UTC)},
+		{PeriodMonth, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := tt.period.Start(at); !got.Equal(tt.want) {
+			t.Errorf("%s Start() = %v, want %v", tt.period, got, tt.want)
+		}
+	}
+
+	// Sunday belongs to the week that started the Monday before
+	sunday := time.Date(2025, 5, 18, 10, 0, 0, 0, time.UTC)
+	if got := PeriodWeek.Start(sunday); !got.Equal(time.Date(2025, 5, 12, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("week Start(Sunday) = %v, want Monday 2025-05-12", got)
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	for _, name := range []string{"day", "week", "month"} {
+		if got, err := ParsePeriod(name); err != nil || string(got) != name {
+			t.Errorf("ParsePeriod(%q) = %q, %v", name, got, err)
+		}
+	}
+	if _, err := ParsePeriod("year"); err == nil {
+		t.Error("ParsePeriod(\"year\") expected an error")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	summary := Summarize([]types.TypingSession{
+		{WPM: 40, Accuracy: 90, Errors: 5, Duration: time.Minute},
+		{WPM: 60, Accuracy: 100, Errors: 0, Duration: 2 * time.Minute},
+	})
+	want := Summary{Sessions: 2, AvgWPM: 50, BestWPM: 60, AvgAccuracy: 95, Errors: 5, Duration: 3 * time.Minute}
+	if summary != want {
+		t.Errorf("Summarize() = %+v, want %+v", summary, want)
+	}
+
+	if empty := Summarize(nil); empty != (Summary{}) {
+		t.Errorf("Summarize(nil) = %+v, want zero", empty)
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2025, 5, d, hour, 0, 0, 0, time.Local) }
+	sessions := []types.TypingSession{
+		{Date: day(20, 9), WPM: 70},
+		{Date: day(12, 9), WPM: 40},
+		{Date: day(14, 9), WPM: 50},
+		{Date: day(12, 18), WPM: 60},
+	}
+
+	tests := []struct {
+		period   Period
+		starts   []time.Time
+		sessions []int
+	}{
+		{PeriodDay, []time.Time{day(12, 0), day(14, 0), day(20, 0)}, []int{2, 1, 1}},
+		{PeriodWeek, []time.Time{day(12, 0), day(19, 0)}, []int{3, 1}},
+		{PeriodMonth, []time.Time{day(1, 0)}, []int{4}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.period), func(t *testing.T) {
+			got := Aggregate(sessions, tt.period)
+			if len(got) != len(tt.starts) {
+				t.Fatalf("Aggregate() = %+v, want %d periods", got, len(tt.starts))
+			}
+			for i, summary := range got {
+				if !summary.Start.Equal(tt.starts[i]) || summary.Sessions != tt.sessions[i] {
+					t.Errorf("period %d = %v with %d sessions, want %v with %d",
+						i, summary.Start, summary.Sessions, tt.starts[i], tt.sessions[i])
+				}
+			}
+		})
+	}
+}
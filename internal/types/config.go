@@ -1,37 +1,81 @@
 package types
 
 import (
+	"fmt"
 	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Text  TextConfig  `yaml:"text"`
-	Ui    UiConfig    `yaml:"ui"`
-	Stats StatsConfig `yaml:"stats"`
+	Text    TextConfig    `yaml:"text"`
+	Ui      UiConfig      `yaml:"ui"`
+	Session SessionConfig `yaml:"session"`
+	Stats   StatsConfig   `yaml:"stats"`
+	Goals   GoalsConfig   `yaml:"goals"`
 }
 
 type UiConfig struct {
 	Theme               string `yaml:"theme"`
 	BlockOnTypo         bool   `yaml:"block_on_typo"`          // Block further input when a typo is detected
+	Backspace           string `yaml:"backspace"`              // Backspace policy: "unlimited", "word" or "disabled"
+	ErrorMode           string `yaml:"error_mode"`             // Error handling: "normal", "fix_word" or "skip"
 	TypoFlashEnabled    bool   `yaml:"typo_flash_enabled"`     // Enable red flash visual feedback on typo
 	TypoFlashDurationMs int    `yaml:"typo_flash_duration_ms"` // Duration of red flash in milliseconds
+	Layout              string `yaml:"layout"`                 // Text layout: "line" (scrolling ticker) or "paragraph" (word-wrapped)
+	LayoutLines         int    `yaml:"layout_lines"`           // Visible lines in paragraph layout (3-5)
+	KeyboardLayout      string `yaml:"keyboard_layout"`        // Keyboard layout name, built-in or a YAML file in <config dir>/layouts
+	ShowKeyboard        bool   `yaml:"show_keyboard"`          // Show the on-screen keyboard with next-key hints
+	PaceCaret           string `yaml:"pace_caret"`             // Pace caret target: "off", "fixed", "average" or "best"
+	PaceCaretWPM        int    `yaml:"pace_caret_wpm"`         // Target WPM for the fixed pace caret
 }
 
 type TextConfig struct {
-	Source string    `yaml:"source"`
-	LLM    LLMConfig `yaml:"llm"`
+	Source     string           `yaml:"source"`
+	LLM        LLMConfig        `yaml:"llm"`
+	Difficulty DifficultyConfig `yaml:"difficulty"`
+}
+
+type DifficultyConfig struct {
+	TargetAccuracyMin float32 `yaml:"target_accuracy_min"` // Lower bound of the accuracy band in percent
+	TargetAccuracyMax float32 `yaml:"target_accuracy_max"` // Upper bound of the accuracy band in percent
 }
 
 type LLMConfig struct {
-	Provider              string `yaml:"provider"`               // Provider: anthropic, openai, ollama
-	Model                 string `yaml:"model"`                  // Model name (e.g., claude-3-5-haiku-latest, gpt-4, llama2)
-	APIBase               string `yaml:"api_base,omitempty"`     // Optional: Custom API endpoint (e.g., for Ollama: http://localhost:11434)
-	PregenerateThreshold  int    `yaml:"pregenerate_threshold"`
-	FallbackToDummy       bool   `yaml:"fallback_to_dummy"`
-	TimeoutSeconds        int    `yaml:"timeout_seconds"`
-	MaxRetries            int    `yaml:"max_retries"`
+	Provider             string `yaml:"provider"`           // Provider: anthropic, openai, ollama
+	Model                string `yaml:"model"`              // Model name (e.g., claude-3-5-haiku-latest, gpt-4, llama2)
+	APIBase              string `yaml:"api_base,omitempty"` // Optional: Custom API endpoint (e.g., for Ollama: http://localhost:11434)
+	PregenerateThreshold int    `yaml:"pregenerate_threshold"`
+	FallbackToDummy      bool   `yaml:"fallback_to_dummy"`
+	TimeoutSeconds       int    `yaml:"timeout_seconds"`
+	MaxRetries           int    `yaml:"max_retries"`
+}
+
+type SessionConfig struct {
+	DefaultDuration      string `yaml:"default_duration"`       // Preselected duration, e.g. "30s", "2m" or "1m30s"
+	IdleThresholdSeconds int    `yaml:"idle_threshold_seconds"` // Inactivity before auto-pause; 0 uses the default, negative disables
+}
+
+type GoalsConfig struct {
+	MinutesPerDay   int     `yaml:"minutes_per_day"`   // Typing time to aim for each day; 0 disables
+	SessionsPerWeek int     `yaml:"sessions_per_week"` // Sessions to aim for each week, Monday to Sunday; 0 disables
+	TargetWPM       float32 `yaml:"target_wpm"`        // Speed to work towards; 0 disables
+	TargetDate      string  `yaml:"target_date"`       // Date to reach target_wpm by, e.g. "2025-12-31"; optional
+}
+
+// Deadline returns the target date at midnight in loc, zero when none is set
+func (g GoalsConfig) Deadline(loc *time.Location) (time.Time, error) {
+	if g.TargetDate == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", g.TargetDate, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid goals.target_date %q, want a date like 2025-12-31", g.TargetDate)
+	}
+	return date, nil
 }
 
 type StatsConfig struct {
@@ -48,5 +92,28 @@ func LoadConfig(dir string) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	if _, err := config.Goals.Deadline(time.Local); err != nil {
+		return nil, err
+	}
 	return &config, nil
 }
+
+// ParseSessionDuration parses a session duration such as "30s", "2m" or "1m30s".
+// A bare number is read as seconds.
+func ParseSessionDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		value = fmt.Sprintf("%ds", seconds)
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration must be positive: %q", value)
+	}
+	return duration, nil
+}
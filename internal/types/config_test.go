@@ -3,7 +3,9 @@ package types
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadConfig_ValidConfig(t *testing.T) {
@@ -205,3 +207,73 @@ func TestLoadConfig_MinimalConfig(t *testing.T) {
 		t.Logf("Note: config.Ui.Theme = %q (default value)", config.Ui.Theme)
 	}
 }
+
+func TestLoadConfig_SessionDefaultDuration(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	sessionConfig := `text:
+  source: dummy
+session:
+  default_duration: 30s
+`
+
+	err := os.WriteFile(configPath, []byte(sessionConfig), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+
+	if config.Session.DefaultDuration != "30s" {
+		t.Errorf("config.Session.DefaultDuration = %q, want %q", config.Session.DefaultDuration, "30s")
+	}
+}
+
+func TestParseSessionDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30s", 30 * time.Second, false},
+		{"2m", 2 * time.Minute, false},
+		{"1m30s", 90 * time.Second, false},
+		{"45", 45 * time.Second, false},
+		{" 15s ", 15 * time.Second, false},
+		{"", 0, true},
+		{"abc", 0, true},
+		{"0", 0, true},
+		{"-5s", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSessionDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSessionDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSessionDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_InvalidTargetDate(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("goals:\n  target_wpm: 60\n  target_date: \"soon\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	config, err := LoadConfig(configPath)
+	if err == nil || !strings.Contains(err.Error(), "goals.target_date") {
+		t.Errorf("LoadConfig() error = %v, want it to name goals.target_date", err)
+	}
+	if config != nil {
+		t.Errorf("LoadConfig() expected nil config for an invalid target date, got %v", config)
+	}
+}
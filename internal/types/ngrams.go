@@ -0,0 +1,111 @@
+package types
+
+import (
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// ngramWindow caps the sample count in rolling averages so recent sessions keep moving the mean
+const ngramWindow = 50
+
+// NGramStat aggregates the transition times of one n-gram within a session
+type NGramStat struct {
+	Count int           `json:"count"`
+	Total time.Duration `json:"total"` // Sum of the time from the first to the last character
+}
+
+// Add returns the combined stats of s and other
+func (s NGramStat) Add(other NGramStat) NGramStat {
+	return NGramStat{Count: s.Count + other.Count, Total: s.Total + other.Total}
+}
+
+// Mean returns the average transition time
+func (s NGramStat) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// RollingNGram is the long-term transition time of an n-gram across sessions
+type RollingNGram struct {
+	Mean    time.Duration `json:"mean"`
+	Samples int           `json:"samples"`
+	Updated time.Time     `json:"updated"`
+}
+
+// NGramStats holds the rolling bigram and trigram transition times
+type NGramStats struct {
+	Bigrams  map[string]RollingNGram `json:"bigrams"`
+	Trigrams map[string]RollingNGram `json:"trigrams"`
+}
+
+// SlowNGram is one of the slowest n-grams of a session
+type SlowNGram struct {
+	NGram string        `json:"ngram"`
+	Mean  time.Duration `json:"mean"`
+	Count int           `json:"count"`
+	Words []string      `json:"words,omitempty"` // Words from the session text containing the n-gram
+}
+
+// Update folds a session's n-gram stats into the rolling averages. Older samples
+// are capped at ngramWindow so the averages follow the user's progress.
+func (s *NGramStats) Update(bigrams, trigrams map[string]NGramStat, at time.Time) {
+	if s.Bigrams == nil {
+		s.Bigrams = make(map[string]RollingNGram)
+	}
+	if s.Trigrams == nil {
+		s.Trigrams = make(map[string]RollingNGram)
+	}
+	updateRolling(s.Bigrams, bigrams, at)
+	updateRolling(s.Trigrams, trigrams, at)
+}
+
+func updateRolling(rolling map[string]RollingNGram, session map[string]NGramStat, at time.Time) {
+	for ngram, stat := range session {
+		if stat.Count == 0 {
+			continue
+		}
+		current := rolling[ngram]
+		weight := current.Samples
+		if weight > ngramWindow {
+			weight = ngramWindow
+		}
+		mean := (current.Mean*time.Duration(weight) + stat.Total) / time.Duration(weight+stat.Count)
+		rolling[ngram] = RollingNGram{Mean: mean, Samples: current.Samples + stat.Count, Updated: at}
+	}
+}
+
+// PerTransition returns the mean time of one key-to-key transition of an
+// n-gram. A trigram spans two transitions, so its mean is halved to rank it
+// fairly against bigrams.
+func PerTransition(ngram string, mean time.Duration) time.Duration {
+	transitions := utf8.RuneCountInString(ngram) - 1
+	if transitions < 1 {
+		return mean
+	}
+	return mean / time.Duration(transitions)
+}
+
+// Slowest returns up to n n-grams with the highest mean transition time,
+// ignoring those with fewer than minSamples samples
+func Slowest(rolling map[string]RollingNGram, n int, minSamples int) []string {
+	var ngrams []string
+	for ngram, stat := range rolling {
+		if stat.Samples >= minSamples {
+			ngrams = append(ngrams, ngram)
+		}
+	}
+	sort.Slice(ngrams, func(i, j int) bool {
+		a, b := rolling[ngrams[i]].Mean, rolling[ngrams[j]].Mean
+		if a != b {
+			return a > b
+		}
+		return ngrams[i] < ngrams[j]
+	})
+	if len(ngrams) > n {
+		ngrams = ngrams[:n]
+	}
+	return ngrams
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNGramStats_Update(t *testing.T) {
+	var stats NGramStats
+	now := time.Now()
+
+	stats.Update(map[string]NGramStat{"th": {Count: 2, Total: 400 * time.Millisecond}}, nil, now)
+	if got := stats.Bigrams["th"]; got.Mean != 200*time.Millisecond || got.Samples != 2 {
+		t.Errorf("Bigrams[th] = %+v, want mean 200ms over 2 samples", got)
+	}
+
+	stats.Update(map[string]NGramStat{"th": {Count: 2, Total: 200 * time.Millisecond}}, nil, now)
+	if got := stats.Bigrams["th"]; got.Mean != 150*time.Millisecond || got.Samples != 4 {
+		t.Errorf("Bigrams[th] = %+v, want mean 150ms over 4 samples", got)
+	}
+}
+
+func TestNGramStats_UpdateCapsWindow(t *testing.T) {
+	stats := NGramStats{Bigrams: map[string]RollingNGram{"th": {Mean: 300 * time.Millisecond, Samples: 1000}}}
+
+	stats.Update(map[string]NGramStat{"th": {Count: 50, Total: 50 * 100 * time.Millisecond}}, nil, time.Now())
+
+	// With the history capped at 50 samples the new session weighs half
+	if got := stats.Bigrams["th"].Mean; got != 200*time.Millisecond {
+		t.Errorf("Bigrams[th].Mean = %v, want 200ms", got)
+	}
+}
+
+func TestSlowest(t *testing.T) {
+	rolling := map[string]RollingNGram{
+		"th": {Mean: 100 * time.Millisecond, Samples: 10},
+		"qu": {Mean: 300 * time.Millisecond, Samples: 10},
+		"zx": {Mean: 900 * time.Millisecond, Samples: 1},
+		"ck": {Mean: 200 * time.Millisecond, Samples: 10},
+	}
+
+	got := Slowest(rolling, 2, 5)
+	if len(got) != 2 || got[0] != "qu" || got[1] != "ck" {
+		t.Errorf("Slowest() = %v, want [qu ck]", got)
+	}
+}
+
+func TestPerTransition(t *testing.T) {
+	tests := []struct {
+		ngram string
+		mean  time.Duration
+		want  time.Duration
+	}{
+		{"th", 200 * time.Millisecond, 200 * time.Millisecond},
+		{"the", 300 * time.Millisecond, 150 * time.Millisecond},
+		{"é", 100 * time.Millisecond, 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := PerTransition(tt.ngram, tt.mean); got != tt.want {
+			t.Errorf("PerTransition(%q, %v) = %v, want %v", tt.ngram, tt.mean, got, tt.want)
+		}
+	}
+}
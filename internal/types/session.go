@@ -1,15 +1,135 @@
 package types
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TestMode identifies the end condition of a typing session
+type TestMode string
+
+const (
+	ModeTime        TestMode = "time"         // Ends when the selected duration expires
+	ModeWords       TestMode = "words"        // Ends after a fixed number of words
+	ModeText        TestMode = "text"         // Ends when the given text is complete
+	ModeZen         TestMode = "zen"          // Untimed, ends when the user finishes it
+	ModeSuddenDeath TestMode = "sudden_death" // Ends on the first error
+)
+
+// ParseTestMode checks a mode name given on the command line, ignoring case
+func ParseTestMode(name string) (TestMode, error) {
+	switch mode := TestMode(strings.ToLower(name)); mode {
+	case ModeTime, ModeWords, ModeText, ModeZen, ModeSuddenDeath:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown mode %q, want time, words, text, zen or sudden_death", name)
+}
 
 type TypingSession struct {
-	Date     time.Time     `json:"date"`
-	WPM      float32       `json:"wpm"`
-	Accuracy float32       `json:"accuracy"`
-	Errors   int           `json:"errors"`
+	Date      time.Time     `json:"date"`
+	WPM       float32       `json:"wpm"`
+	Accuracy  float32       `json:"accuracy"`
+	Errors    int           `json:"errors"`
+	Duration  time.Duration `json:"duration"`
+	Mode      TestMode      `json:"mode,omitempty"`
+	WordCount int           `json:"word_count,omitempty"` // Target word count in words mode
+
+	// Detailed metrics; WPM above holds the net WPM for sessions that have them
+	GrossWPM          float32 `json:"gross_wpm,omitempty"`
+	NetWPM            float32 `json:"net_wpm,omitempty"`
+	RawWPM            float32 `json:"raw_wpm,omitempty"`
+	CorrectedErrors   int     `json:"corrected_errors,omitempty"`
+	UncorrectedErrors int     `json:"uncorrected_errors,omitempty"`
+	Consistency       float32 `json:"consistency,omitempty"` // 0-100 score from per-second speed variance
+
+	Pauses     []Pause       `json:"pauses,omitempty"`
+	PausedTime time.Duration `json:"paused_time,omitempty"` // Total paused time, excluded from Duration
+
+	KeyStats   map[string]KeyStat        `json:"key_stats,omitempty"`   // Per expected character
+	Confusions map[string]map[string]int `json:"confusions,omitempty"`  // Expected character -> typed character -> count
+	SlowNGrams []SlowNGram               `json:"slow_ngrams,omitempty"` // Slowest bigrams and trigrams of the session
+
+	MistypedWords []MistypedWord `json:"mistyped_words,omitempty"`
+	Drill         bool           `json:"drill,omitempty"` // Session drilled the mistyped words of the previous one
+
+	// What the session was typed with, for filtering history
+	Source         string        `json:"source,omitempty"`          // Text source type: dummy, llm, practice or drill
+	Provider       string        `json:"provider,omitempty"`        // LLM provider of the llm source
+	Model          string        `json:"model,omitempty"`           // LLM model of the llm source
+	TargetDuration time.Duration `json:"target_duration,omitempty"` // Selected duration in time mode
+	KeyboardLayout string        `json:"keyboard_layout,omitempty"`
+	Theme          string        `json:"theme,omitempty"`
+	AppVersion     string        `json:"app_version,omitempty"`
+	TextHash       string        `json:"text_hash,omitempty"` // Short SHA-256 of the text shown; sessions on the same text share it
+}
+
+// MistypedWord is a word of the target text that was not typed right at the first attempt
+type MistypedWord struct {
+	Target string `json:"target"`
+	Typed  string `json:"typed"` // First attempt at each character, shorter than Target if abandoned
+	Count  int    `json:"count"` // Times the word was mistyped in the session
+}
+
+// KeyStat aggregates the key presses made for one expected character
+type KeyStat struct {
+	Presses int           `json:"presses"`
+	Errors  int           `json:"errors"`
+	Latency time.Duration `json:"latency"` // Total time since the previous keystroke over all presses
+}
+
+// Add returns the combined stats of s and other
+func (s KeyStat) Add(other KeyStat) KeyStat {
+	return KeyStat{
+		Presses: s.Presses + other.Presses,
+		Errors:  s.Errors + other.Errors,
+		Latency: s.Latency + other.Latency,
+	}
+}
+
+// ErrorRate returns the fraction of presses that were wrong
+func (s KeyStat) ErrorRate() float64 {
+	if s.Presses == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Presses)
+}
+
+// MeanLatency returns the average time taken to reach the key
+func (s KeyStat) MeanLatency() time.Duration {
+	if s.Presses == 0 {
+		return 0
+	}
+	return s.Latency / time.Duration(s.Presses)
+}
+
+// Pause is a period during a session in which the clock was stopped
+type Pause struct {
+	Start    time.Time     `json:"start"`
 	Duration time.Duration `json:"duration"`
+	Auto     bool          `json:"auto"` // Triggered by idle detection rather than the pause key
+}
+
+// SessionMode returns the session's test mode, treating records saved
+// before modes existed as time-boxed sessions
+func (s TypingSession) SessionMode() TestMode {
+	if s.Mode == "" {
+		return ModeTime
+	}
+	return s.Mode
+}
+
+// WasIdle reports whether the session was auto-paused because the user went idle
+func (s TypingSession) WasIdle() bool {
+	for _, pause := range s.Pauses {
+		if pause.Auto {
+			return true
+		}
+	}
+	return false
 }
 
 type UserStats struct {
+	Version  int             `json:"version,omitempty"` // Schema version of the stored history, current once loaded
 	Sessions []TypingSession `json:"sessions"`
 }
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"go-touch/internal/metrics"
+	"go-touch/internal/sources"
+	"go-touch/internal/types"
+)
+
+const (
+	difficultyWindow     = 100 // Recent keystrokes the difficulty controller looks at
+	minDifficultySamples = 30  // Keystrokes needed before the difficulty changes
+	defaultAccuracyMin   = 94  // Lower edge of the target accuracy band
+	defaultAccuracyMax   = 97  // Upper edge of the target accuracy band
+)
+
+// accuracyBand returns the configured target accuracy range
+func accuracyBand(config types.Config) (float32, float32) {
+	low, high := config.Text.Difficulty.TargetAccuracyMin, config.Text.Difficulty.TargetAccuracyMax
+	if low <= 0 || high <= 0 || low > high {
+		return defaultAccuracyMin, defaultAccuracyMax
+	}
+	return low, high
+}
+
+// typingPerformance returns accuracy and WPM over the given keystrokes, ignoring backspaces
+func typingPerformance(keystrokes []metrics.Keystroke) (accuracy, wpm float32, samples int) {
+	correct := 0
+	for _, k := range keystrokes {
+		if k.Backspace {
+			continue
+		}
+		samples++
+		if k.Correct() {
+			correct++
+		}
+	}
+	if samples == 0 {
+		return 0, 0, 0
+	}
+
+	accuracy = float32(correct) / float32(samples) * 100
+	if elapsed := keystrokes[len(keystrokes)-1].At - keystrokes[0].At; elapsed > 0 {
+		wpm = float32(samples) / 5 / float32(elapsed.Minutes())
+	}
+	return accuracy, wpm, samples
+}
+
+// recentKeystrokes returns the last difficultyWindow keystrokes
+func (m sessionModel) recentKeystrokes() []metrics.Keystroke {
+	if len(m.keystrokes) <= difficultyWindow {
+		return m.keystrokes
+	}
+	return m.keystrokes[len(m.keystrokes)-difficultyWindow:]
+}
+
+// adjustDifficulty moves the difficulty one level towards the target accuracy band.
+// Text only gets harder while the typist keeps up their pace, so accuracy bought by
+// slowing down does not count as headroom.
+func (m *sessionModel) adjustDifficulty() {
+	accuracy, wpm, samples := typingPerformance(m.recentKeystrokes())
+	if samples < minDifficultySamples {
+		return
+	}
+
+	low, high := accuracyBand(m.config)
+	_, averageWPM, _ := typingPerformance(m.keystrokes)
+	switch {
+	case accuracy > high && wpm >= averageWPM*0.9 && m.difficultyLevel < len(sources.DifficultyLevels)-1:
+		m.difficultyLevel++
+	case accuracy < low && m.difficultyLevel > 0:
+		m.difficultyLevel--
+	}
+}
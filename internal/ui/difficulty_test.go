@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"go-touch/internal/metrics"
+	"go-touch/internal/sources"
+	"go-touch/internal/types"
+	"testing"
+	"time"
+)
+
+// keystrokesAt builds n keystrokes with the given number of errors, one every interval
+func keystrokesAt(n, errors int, interval time.Duration) []metrics.Keystroke {
+	keystrokes := make([]metrics.Keystroke, n)
+	for i := range keystrokes {
+		keystrokes[i] = metrics.Keystroke{At: time.Duration(i) * interval, Pos: i, Expected: 'a', Typed: 'a'}
+		if i < errors {
+			keystrokes[i].Typed = 's'
+		}
+	}
+	return keystrokes
+}
+
+func TestAccuracyBand(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max float32
+		wantLow  float32
+		wantHigh float32
+	}{
+		{"configured", 90, 95, 90, 95},
+		{"unset", 0, 0, defaultAccuracyMin, defaultAccuracyMax},
+		{"inverted", 97, 94, defaultAccuracyMin, defaultAccuracyMax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := types.Config{}
+			config.Text.Difficulty = types.DifficultyConfig{TargetAccuracyMin: tt.min, TargetAccuracyMax: tt.max}
+			low, high := accuracyBand(config)
+			if low != tt.wantLow || high != tt.wantHigh {
+				t.Errorf("accuracyBand() = %v, %v, want %v, %v", low, high, tt.wantLow, tt.wantHigh)
+			}
+		})
+	}
+}
+
+func TestTypingPerformance(t *testing.T) {
+	// 50 keys at 200ms: 10 words in 9.8 seconds
+	keystrokes := append(keystrokesAt(50, 5, 200*time.Millisecond), metrics.Keystroke{At: 10 * time.Second, Backspace: true})
+	accuracy, wpm, samples := typingPerformance(keystrokes)
+	if samples != 50 {
+		t.Errorf("typingPerformance() samples = %d, want 50", samples)
+	}
+	if accuracy != 90 {
+		t.Errorf("typingPerformance() accuracy = %v, want 90", accuracy)
+	}
+	if wpm < 59 || wpm > 61 {
+		t.Errorf("typingPerformance() wpm = %v, want about 60", wpm)
+	}
+
+	if _, _, samples := typingPerformance(nil); samples != 0 {
+		t.Errorf("typingPerformance(nil) samples = %d, want 0", samples)
+	}
+}
+
+func TestAdjustDifficulty(t *testing.T) {
+	hardest := len(sources.DifficultyLevels) - 1
+	tests := []struct {
+		name       string
+		keystrokes []metrics.Keystroke
+		level      int
+		want       int
+	}{
+		{"too few keystrokes", keystrokesAt(20, 0, 200*time.Millisecond), 2, 2},
+		{"accuracy above band", keystrokesAt(100, 1, 200*time.Millisecond), 2, 3},
+		{"accuracy in band", keystrokesAt(100, 5, 200*time.Millisecond), 2, 2},
+		{"accuracy below band", keystrokesAt(100, 10, 200*time.Millisecond), 2, 1},
+		{"already hardest", keystrokesAt(100, 0, 200*time.Millisecond), hardest, hardest},
+		{"already easiest", keystrokesAt(100, 20, 200*time.Millisecond), 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := sessionModel{keystrokes: tt.keystrokes, difficultyLevel: tt.level}
+			m.adjustDifficulty()
+			if m.difficultyLevel != tt.want {
+				t.Errorf("adjustDifficulty() level = %d, want %d", m.difficultyLevel, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdjustDifficulty_SlowingDown(t *testing.T) {
+	// Fast and sloppy earlier, accurate now only by typing at half the pace
+	keystrokes := keystrokesAt(100, 30, 100*time.Millisecond)
+	for _, k := range keystrokesAt(100, 0, 200*time.Millisecond) {
+		k.At += 10 * time.Second
+		keystrokes = append(keystrokes, k)
+	}
+
+	m := sessionModel{keystrokes: keystrokes, difficultyLevel: 2}
+	m.adjustDifficulty()
+	if m.difficultyLevel != 2 {
+		t.Errorf("adjustDifficulty() level = %d, want 2 while the typist slows down", m.difficultyLevel)
+	}
+}
+
+func TestPracticeHints_Difficulty(t *testing.T) {
+	m := sessionModel{difficultyLevel: 4}
+	if got := m.practiceHints().Difficulty; got != sources.DifficultyLevels[4] {
+		t.Errorf("practiceHints().Difficulty = %+v, want %+v", got, sources.DifficultyLevels[4])
+	}
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"go-touch/internal/types"
+	"time"
+)
+
+const (
+	minSessionDuration     = 5 * time.Second
+	maxSessionDuration     = 60 * time.Minute
+	defaultSessionDuration = time.Minute
+)
+
+// durationPresets are the durations the ↑/↓ keys step through in time mode
+var durationPresets = []time.Duration{
+	15 * time.Second,
+	30 * time.Second,
+	60 * time.Second,
+	2 * time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	15 * time.Minute,
+	30 * time.Minute,
+	60 * time.Minute,
+}
+
+// stepDuration moves to the next larger (delta > 0) or smaller (delta < 0) preset.
+// Custom durations between presets snap to the neighbouring preset.
+func stepDuration(current time.Duration, delta int) time.Duration {
+	if delta > 0 {
+		for _, preset := range durationPresets {
+			if preset > current {
+				return preset
+			}
+		}
+		return durationPresets[len(durationPresets)-1]
+	}
+	for i := len(durationPresets) - 1; i >= 0; i-- {
+		if durationPresets[i] < current {
+			return durationPresets[i]
+		}
+	}
+	return durationPresets[0]
+}
+
+// parseCustomDuration validates a duration typed on the configuration screen
+func parseCustomDuration(input string) (time.Duration, error) {
+	duration, err := types.ParseSessionDuration(input)
+	if err != nil {
+		return 0, err
+	}
+	if duration < minSessionDuration || duration > maxSessionDuration {
+		return 0, fmt.Errorf("duration must be between %s and %s", durationLabel(minSessionDuration), durationLabel(maxSessionDuration))
+	}
+	return duration, nil
+}
+
+// defaultDuration returns the configured default duration, falling back to one minute
+func defaultDuration(config types.Config) time.Duration {
+	if config.Session.DefaultDuration == "" {
+		return defaultSessionDuration
+	}
+	duration, err := parseCustomDuration(config.Session.DefaultDuration)
+	if err != nil {
+		return defaultSessionDuration
+	}
+	return duration
+}
+
+// durationLabel formats a duration for the configuration screen, e.g. "30 seconds", "2 minutes" or "1m 30s"
+func durationLabel(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		seconds := int(d.Seconds())
+		if seconds == 1 {
+			return "1 second"
+		}
+		return fmt.Sprintf("%d seconds", seconds)
+	case d%time.Minute == 0:
+		minutes := int(d.Minutes())
+		if minutes == 1 {
+			return "1 minute"
+		}
+		return fmt.Sprintf("%d minutes", minutes)
+	default:
+		return fmt.Sprintf("%dm %ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+}
+
+// isCustomDurationKey reports whether a key can be part of a typed duration like "90", "45s" or "1m30s"
+func isCustomDurationKey(key string) bool {
+	if len(key) != 1 {
+		return false
+	}
+	c := key[0]
+	return (c >= '0' && c <= '9') || c == 's' || c == 'm'
+}
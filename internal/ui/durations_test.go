@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"go-touch/internal/types"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestStepDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		current time.Duration
+		delta   int
+		want    time.Duration
+	}{
+		{"up from preset", 15 * time.Second, 1, 30 * time.Second},
+		{"down from preset", 2 * time.Minute, -1, time.Minute},
+		{"up from custom snaps to next preset", 45 * time.Second, 1, time.Minute},
+		{"down from custom snaps to previous preset", 45 * time.Second, -1, 30 * time.Second},
+		{"caps at largest preset", 60 * time.Minute, 1, 60 * time.Minute},
+		{"floors at smallest preset", 15 * time.Second, -1, 15 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stepDuration(tt.current, tt.delta); got != tt.want {
+				t.Errorf("stepDuration(%v, %d) = %v, want %v", tt.current, tt.delta, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationLabel(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{15 * time.Second, "15 seconds"},
+		{time.Minute, "1 minute"},
+		{5 * time.Minute, "5 minutes"},
+		{90 * time.Second, "1m 30s"},
+	}
+
+	for _, tt := range tests {
+		if got := durationLabel(tt.duration); got != tt.want {
+			t.Errorf("durationLabel(%v) = %q, want %q", tt.duration, got, tt.want)
+		}
+	}
+}
+
+func TestParseCustomDuration(t *testing.T) {
+	if got, err := parseCustomDuration("45s"); err != nil || got != 45*time.Second {
+		t.Errorf("parseCustomDuration(45s) = (%v, %v), want 45s", got, err)
+	}
+	if _, err := parseCustomDuration("2s"); err == nil {
+		t.Error("parseCustomDuration(2s) should reject durations below the minimum")
+	}
+	if _, err := parseCustomDuration("90m"); err == nil {
+		t.Error("parseCustomDuration(90m) should reject durations above the maximum")
+	}
+}
+
+func TestDefaultDuration(t *testing.T) {
+	config := types.Config{Session: types.SessionConfig{DefaultDuration: "30s"}}
+	if got := defaultDuration(config); got != 30*time.Second {
+		t.Errorf("defaultDuration() = %v, want 30s", got)
+	}
+
+	config.Session.DefaultDuration = "nonsense"
+	if got := defaultDuration(config); got != time.Minute {
+		t.Errorf("defaultDuration() with invalid value = %v, want 1m", got)
+	}
+
+	if got := defaultDuration(types.Config{}); got != time.Minute {
+		t.Errorf("defaultDuration() with no value = %v, want 1m", got)
+	}
+}
+
+func TestSessionModel_CustomDurationInput(t *testing.T) {
+	model := sessionModel{
+		text:             "test text",
+		hasStarted:       false,
+		selectedDuration: time.Minute,
+	}
+
+	var updated tea.Model = model
+	for _, r := range "45s" {
+		updated, _ = updated.(sessionModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m := updated.(sessionModel)
+	if m.customDuration != "45s" {
+		t.Fatalf("customDuration = %q, want %q", m.customDuration, "45s")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(sessionModel)
+	if !m.hasStarted {
+		t.Fatal("Session should start with a valid custom duration")
+	}
+	if m.sessionDuration != 45*time.Second {
+		t.Errorf("sessionDuration = %v, want 45s", m.sessionDuration)
+	}
+}
+
+func TestSessionModel_InvalidCustomDuration(t *testing.T) {
+	model := sessionModel{
+		text:             "test text",
+		hasStarted:       false,
+		selectedDuration: time.Minute,
+		customDuration:   "2",
+	}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m := updated.(sessionModel)
+
+	if m.hasStarted {
+		t.Error("Session should not start with an invalid custom duration")
+	}
+	if m.durationErr == "" {
+		t.Error("durationErr should be set for an invalid custom duration")
+	}
+	if !contains(m.View(), "must be between") {
+		t.Error("View should show the duration error")
+	}
+}
+
+func TestSessionModel_ModeChangeDropsCustomDuration(t *testing.T) {
+	model := sessionModel{
+		text:             "test text",
+		hasStarted:       false,
+		selectedDuration: time.Minute,
+		customDuration:   "2",
+		durationErr:      "duration must be between 15 seconds and 10 minutes",
+	}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m := updated.(sessionModel)
+	if m.customDuration != "" || m.durationErr != "" {
+		t.Errorf("After leaving time mode customDuration = %q, durationErr = %q, want both cleared", m.customDuration, m.durationErr)
+	}
+
+	// A duration left over in another mode does not block the start
+	m.customDuration = "2"
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !updated.(sessionModel).hasStarted {
+		t.Error("Enter in words mode should start the session despite a leftover custom duration")
+	}
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"go-touch/internal/keyboard"
+	"go-touch/internal/metrics"
+	"go-touch/internal/types"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// minFingerPresses is the number of presses a finger needs before it can be named weakest or slowest
+const minFingerPresses = 5
+
+// fingerAbbrev is the short label of each finger in the per-finger row
+var fingerAbbrev = map[keyboard.Finger]string{
+	keyboard.LeftPinky:   "LP",
+	keyboard.LeftRing:    "LR",
+	keyboard.LeftMiddle:  "LM",
+	keyboard.LeftIndex:   "LI",
+	keyboard.Thumb:       "Th",
+	keyboard.RightIndex:  "RI",
+	keyboard.RightMiddle: "RM",
+	keyboard.RightRing:   "RR",
+	keyboard.RightPinky:  "RP",
+}
+
+// layout returns the layout the dashboard analyses keys on, QWERTY if none was loaded
+func (m dashboardModel) layout() *keyboard.Layout {
+	if m.keyboard == nil {
+		return keyboard.Default()
+	}
+	return m.keyboard
+}
+
+// handSummary formats the error rate and mean latency of one hand
+func handSummary(name string, stat types.KeyStat) string {
+	return fmt.Sprintf("%s: %.1f%% errors, %dms", DefaultTheme.Muted.Render(name), stat.ErrorRate()*100, stat.MeanLatency().Milliseconds())
+}
+
+// renderFingerAnalysis renders the per-hand and per-finger breakdown of the
+// session with its error classes and most common substitutions
+func (m dashboardModel) renderFingerAnalysis(termWidth int) string {
+	session := m.currentSession
+	if len(session.KeyStats) == 0 {
+		return ""
+	}
+	analysis := metrics.AnalyzeFingers(m.layout(), session.KeyStats, session.Confusions)
+
+	center := lipgloss.NewStyle().Align(lipgloss.Center).Width(termWidth)
+	var lines []string
+
+	lines = append(lines, handSummary("Left hand", analysis.Hands[keyboard.LeftHand])+" | "+handSummary("Right hand", analysis.Hands[keyboard.RightHand]))
+
+	var fingers []string
+	for _, finger := range keyboard.Fingers {
+		stat, ok := analysis.Fingers[finger]
+		if !ok || finger == keyboard.Thumb {
+			continue
+		}
+		fingers = append(fingers, fmt.Sprintf("%s %.0f%%", DefaultTheme.Muted.Render(fingerAbbrev[finger]), stat.ErrorRate()*100))
+	}
+	lines = append(lines, strings.Join(fingers, "  "))
+
+	var extremes []string
+	if finger, ok := analysis.WeakestFinger(minFingerPresses); ok {
+		extremes = append(extremes, fmt.Sprintf("%s: %s (%.0f%% errors)", DefaultTheme.Muted.Render("Weakest finger"), finger, analysis.Fingers[finger].ErrorRate()*100))
+	}
+	if finger, ok := analysis.SlowestFinger(minFingerPresses); ok {
+		extremes = append(extremes, fmt.Sprintf("%s: %s (%dms)", DefaultTheme.Muted.Render("Slowest finger"), finger, analysis.Fingers[finger].MeanLatency().Milliseconds()))
+	}
+	if len(extremes) > 0 {
+		lines = append(lines, strings.Join(extremes, " | "))
+	}
+
+	if total := analysis.Classes[metrics.SameFinger] + analysis.Classes[metrics.WrongFinger] + analysis.Classes[metrics.WrongHand]; total > 0 {
+		lines = append(lines, fmt.Sprintf("%s: same finger (reach) %d • wrong finger %d • wrong hand (coordination) %d",
+			DefaultTheme.Muted.Render("Error types"),
+			analysis.Classes[metrics.SameFinger],
+			analysis.Classes[metrics.WrongFinger],
+			analysis.Classes[metrics.WrongHand]))
+	}
+
+	if confusions := metrics.TopConfusions(session.Confusions, 5); len(confusions) > 0 {
+		swaps := make([]string, len(confusions))
+		for i, c := range confusions {
+			swaps[i] = fmt.Sprintf("%s→%s ×%d", confusionLabel(c.Expected), confusionLabel(c.Typed), c.Count)
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", DefaultTheme.Muted.Render("Common mix-ups"), strings.Join(swaps, ", ")))
+	}
+
+	var s strings.Builder
+	s.WriteString(lipgloss.NewStyle().
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderTop(true).
+		BorderForeground(lipgloss.Color("8")).
+		Padding(0, 2).
+		Align(lipgloss.Center).
+		Width(termWidth - 4).
+		Render(DefaultTheme.Info.Render("Finger Analysis")))
+	s.WriteString("\n\n")
+	for i, line := range lines {
+		if i > 0 {
+			s.WriteString("\n")
+		}
+		s.WriteString(center.Render(line))
+	}
+	return s.String()
+}
+
+// confusionLabel shows whitespace characters by name in the mix-up list
+func confusionLabel(r rune) string {
+	if r == ' ' {
+		return "space"
+	}
+	return string(r)
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"go-touch/internal/types"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDashboardModel_FingerAnalysis(t *testing.T) {
+	session := types.TypingSession{
+		WPM:      50,
+		Accuracy: 95,
+		KeyStats: map[string]types.KeyStat{
+			"e": {Presses: 10, Errors: 3, Latency: 2 * time.Second},
+			"j": {Presses: 10, Latency: time.Second},
+		},
+		Confusions: map[string]map[string]int{"e": {"i": 3}},
+	}
+	model := newDashboardModel(types.Config{}, session, types.UserStats{})
+	model.width = 120
+
+	view := model.View()
+	for _, want := range []string{"Finger Analysis", "Weakest finger", "left middle", "wrong hand (coordination) 3", "e→i ×3"} {
+		if !contains(view, want) {
+			t.Errorf("Dashboard should show %q", want)
+		}
+	}
+}
+
+func TestSessionModel_PracticeHints(t *testing.T) {
+	model := newModeTestModel("", "test text", "te")
+	model.errorPatterns = map[rune]int{'s': 1}
+	model.problemWords = []string{"test"}
+
+	for _, r := range "sr" {
+		updatedModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		model = updatedModel.(sessionModel)
+	}
+
+	hints := model.practiceHints()
+	if len(hints.ErrorChars) != 1 || len(hints.ErrorWords) != 1 {
+		t.Errorf("practiceHints() = %+v, want the tracked error chars and words", hints)
+	}
+	if len(hints.Confusions) != 1 || hints.Confusions[0].Expected != 't' || hints.Confusions[0].Typed != 'r' {
+		t.Errorf("practiceHints().Confusions = %+v, want t->r", hints.Confusions)
+	}
+}
@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"fmt"
+	"go-touch/internal/stats"
+	"go-touch/internal/types"
+	"strings"
+	"time"
+)
+
+const (
+	// calendarWeeks is the number of weeks shown in the practice calendar
+	calendarWeeks = 16
+	// targetWPMSessions is the number of recent sessions averaged for the WPM goal
+	targetWPMSessions = 10
+)
+
+// calendarShades mark a day's typing time from none to the daily goal or more
+var calendarShades = []string{"·", "░", "▒", "▓", "█"}
+
+// goalProgress is the state of the practice goals at one moment
+type goalProgress struct {
+	goals         types.GoalsConfig
+	now           time.Time
+	days          map[time.Time]time.Duration // typing time per practice day
+	todaySessions int
+	weekSessions  int
+	current       int // current daily streak
+	longest       int // longest daily streak
+	recentWPM     float32
+	targetDate    time.Time // zero when no date is set
+}
+
+func newGoalProgress(goals types.GoalsConfig, history types.UserStats, now time.Time) goalProgress {
+	p := goalProgress{goals: goals, now: now, days: stats.PracticeDays(history)}
+	p.current, p.longest = stats.Streaks(history, now)
+
+	today := stats.PeriodDay.Start(now.Local())
+	week := stats.PeriodWeek.Start(now.Local())
+	for _, session := range history.Sessions {
+		date := session.Date.Local()
+		if !date.Before(today) {
+			p.todaySessions++
+		}
+		if !date.Before(week) {
+			p.weekSessions++
+		}
+	}
+
+	recent := history.Sessions[max(0, len(history.Sessions)-targetWPMSessions):]
+	p.recentWPM = stats.Summarize(recent).AvgWPM
+
+	// An invalid date is reported when the config is loaded
+	p.targetDate, _ = goals.Deadline(now.Location())
+	return p
+}
+
+// todayTime returns the time typed today
+func (p goalProgress) todayTime() time.Duration {
+	return p.days[stats.PeriodDay.Start(p.now.Local())]
+}
+
+// reminder nudges the user to practise, empty once today's goal is met
+func (p goalProgress) reminder() string {
+	practised := p.todaySessions > 0
+	dailyGoal := time.Duration(p.goals.MinutesPerDay) * time.Minute
+	switch {
+	case practised && (dailyGoal == 0 || p.todayTime() >= dailyGoal):
+		return ""
+	case practised:
+		remaining := (dailyGoal - p.todayTime() + time.Minute - 1) / time.Minute
+		return fmt.Sprintf("%d min more to reach today's goal", remaining)
+	case p.current > 0:
+		return fmt.Sprintf("Practise today to keep your %d-day streak going!", p.current)
+	case len(p.days) > 0:
+		return "Start a new streak today!"
+	}
+	return ""
+}
+
+// render shows the goals and streaks for the welcome screen, with the
+// practice calendar when there is room for it
+func (p goalProgress) render(calendar bool) string {
+	var lines []string
+
+	if p.goals.MinutesPerDay > 0 {
+		goal := time.Duration(p.goals.MinutesPerDay) * time.Minute
+		lines = append(lines, fmt.Sprintf("%s %s %s / %d min",
+			goalLabel("Today"), goalBar(float64(p.todayTime())/float64(goal), 16),
+			formatMinutes(p.todayTime()), p.goals.MinutesPerDay))
+	} else {
+		lines = append(lines, fmt.Sprintf("%s %d sessions, %s",
+			goalLabel("Today"), p.todaySessions, formatMinutes(p.todayTime())))
+	}
+	if p.goals.SessionsPerWeek > 0 {
+		lines = append(lines, fmt.Sprintf("%s %s %d / %d sessions",
+			goalLabel("Week"), goalBar(float64(p.weekSessions)/float64(p.goals.SessionsPerWeek), 16),
+			p.weekSessions, p.goals.SessionsPerWeek))
+	}
+	if p.goals.TargetWPM > 0 {
+		target := fmt.Sprintf("%s %s %.0f / %.0f WPM",
+			goalLabel("Speed"), goalBar(float64(p.recentWPM/p.goals.TargetWPM), 16),
+			p.recentWPM, p.goals.TargetWPM)
+		if !p.targetDate.IsZero() {
+			if left := int(p.targetDate.Sub(stats.PeriodDay.Start(p.now.Local())).Hours() / 24); left >= 0 {
+				target += DefaultTheme.Muted.Render(fmt.Sprintf(" • %s to %s", daysLabel(left), p.targetDate.Format("Jan 2")))
+			} else {
+				target += DefaultTheme.Muted.Render(" • target date passed")
+			}
+		}
+		lines = append(lines, target)
+	}
+
+	lines = append(lines, fmt.Sprintf("%s %s • %s %s",
+		goalLabel("Streak"), DefaultTheme.Highlight.Render(daysLabel(p.current)),
+		DefaultTheme.Muted.Render("Longest"), daysLabel(p.longest)))
+	if reminder := p.reminder(); reminder != "" {
+		lines = append(lines, DefaultTheme.Warning.Render(reminder))
+	}
+
+	if calendar {
+		lines = append(lines, "", p.renderCalendar())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderCalendar draws the last calendarWeeks weeks of practice, one column
+// per week and one row per weekday, shaded by the time typed that day
+func (p goalProgress) renderCalendar() string {
+	today := stats.PeriodDay.Start(p.now.Local())
+	first := stats.PeriodWeek.Start(today).AddDate(0, 0, -7*(calendarWeeks-1))
+
+	// Shades are relative to the daily goal, or to the busiest day without one
+	full := time.Duration(p.goals.MinutesPerDay) * time.Minute
+	if full == 0 {
+		for _, d := range p.days {
+			full = max(full, d)
+		}
+	}
+
+	labels := []string{"Mon", "", "Wed", "", "Fri", "", "Sun"}
+	var s strings.Builder
+	for weekday := 0; weekday < 7; weekday++ {
+		s.WriteString(DefaultTheme.Muted.Render(fmt.Sprintf("%-4s", labels[weekday])))
+		for week := 0; week < calendarWeeks; week++ {
+			day := first.AddDate(0, 0, 7*week+weekday)
+			if day.After(today) {
+				break
+			}
+			s.WriteString(calendarCell(p.days, day, full) + " ")
+		}
+		s.WriteString("\n")
+	}
+	s.WriteString(DefaultTheme.Muted.Render(fmt.Sprintf("    %s — today   less %s more",
+		first.Format("Jan 2"), strings.Join(calendarShades, ""))))
+	return s.String()
+}
+
+// calendarCell shades one day of the calendar
+func calendarCell(days map[time.Time]time.Duration, day time.Time, full time.Duration) string {
+	typed, ok := days[day]
+	if !ok {
+		return DefaultTheme.Muted.Render(calendarShades[0])
+	}
+	level := len(calendarShades) - 1
+	if full > 0 && typed < full {
+		level = 1 + int(float64(typed)/float64(full)*float64(len(calendarShades)-2))
+	}
+	return DefaultTheme.Highlight.Render(calendarShades[level])
+}
+
+// goalLabel names a line of the goals panel, padded so the bars line up
+func goalLabel(name string) string {
+	return DefaultTheme.Info.Render(fmt.Sprintf("%-6s", name))
+}
+
+// goalBar draws a bar of width characters filled to fraction, capped at full
+func goalBar(fraction float64, width int) string {
+	filled := int(min(max(fraction, 0), 1) * float64(width))
+	return DefaultTheme.ProgressFill.Render(strings.Repeat("█", filled)) +
+		DefaultTheme.ProgressBar.Render(strings.Repeat("░", width-filled))
+}
+
+// formatMinutes shows a typing time in whole minutes
+func formatMinutes(d time.Duration) string {
+	return fmt.Sprintf("%d min", int(d.Minutes()))
+}
+
+// daysLabel shows a number of days
+func daysLabel(days int) string {
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
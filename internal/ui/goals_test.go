@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"go-touch/internal/types"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func goalTestHistory(now time.Time, daysAgo ...int) types.UserStats {
+	var history types.UserStats
+	for _, d := range daysAgo {
+		history.Sessions = append(history.Sessions, types.TypingSession{Date: now.AddDate(0, 0, -d), WPM: 50, Duration: 4 * time.Minute})
+	}
+	return history
+}
+
+func TestGoalProgress_Reminder(t *testing.T) {
+	// A Wednesday evening
+	now := time.Date(2025, 5, 14, 19, 0, 0, 0, time.Local)
+	goals := types.GoalsConfig{MinutesPerDay: 10}
+
+	tests := []struct {
+		name    string
+		history types.UserStats
+		want    string
+	}{
+		{"streak at risk", goalTestHistory(now, 2, 1), "keep your 2-day streak"},
+		{"daily goal short", goalTestHistory(now, 0), "6 min more"},
+		{"daily goal met", goalTestHistory(now, 0, 0, 0), ""},
+		{"streak lost", goalTestHistory(now, 5), "Start a new streak"},
+		{"no history", types.UserStats{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newGoalProgress(goals, tt.history, now).reminder()
+			if (tt.want == "") != (got == "") || !strings.Contains(got, tt.want) {
+				t.Errorf("reminder() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoalProgress_Render(t *testing.T) {
+	now := time.Date(2025, 5, 14, 19, 0, 0, 0, time.Local)
+	goals := types.GoalsConfig{MinutesPerDay: 10, SessionsPerWeek: 5, TargetWPM: 60, TargetDate: "2025-05-24"}
+	progress := newGoalProgress(goals, goalTestHistory(now, 9, 2, 1, 0), now)
+
+	if progress.weekSessions != 3 || progress.todaySessions != 1 || progress.current != 3 {
+		t.Errorf("newGoalProgress() week %d, today %d, streak %d, want 3, 1 and 3",
+			progress.weekSessions, progress.todaySessions, progress.current)
+	}
+
+	view := progress.render(true)
+	for _, want := range []string{"4 min / 10 min", "3 / 5 sessions", "50 / 60 WPM", "10 days to May 24", "Streak", "3 days", "Mon", "today"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("render() missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(progress.render(false), "Mon") {
+		t.Error("render(false) should leave out the calendar")
+	}
+}
+
+func TestGoalProgress_InvalidTargetDate(t *testing.T) {
+	now := time.Date(2025, 5, 14, 19, 0, 0, 0, time.Local)
+	progress := newGoalProgress(types.GoalsConfig{TargetWPM: 60, TargetDate: "soon"}, types.UserStats{}, now)
+	if !progress.targetDate.IsZero() {
+		t.Errorf("newGoalProgress() target date = %v, want none for an invalid date", progress.targetDate)
+	}
+}
+
+func TestCalendarCell(t *testing.T) {
+	day := time.Date(2025, 5, 14, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		typed time.Duration
+		want  string
+	}{
+		{time.Minute, "░"},
+		{5 * time.Minute, "▒"},
+		{8 * time.Minute, "▓"},
+		{10 * time.Minute, "█"},
+		{30 * time.Minute, "█"},
+	}
+	for _, tt := range tests {
+		days := map[time.Time]time.Duration{day: tt.typed}
+		if got := calendarCell(days, day, 10*time.Minute); !strings.Contains(got, tt.want) {
+			t.Errorf("calendarCell(%v) = %q, want %q", tt.typed, got, tt.want)
+		}
+	}
+	if got := calendarCell(nil, day, 10*time.Minute); !strings.Contains(got, "·") {
+		t.Errorf("calendarCell() of a day off = %q, want ·", got)
+	}
+}
+
+func TestWelcomeModel_CalendarNeedsRoom(t *testing.T) {
+	history := goalTestHistory(time.Now(), 1, 0)
+	model := newWelcomeModel(types.Config{Goals: types.GoalsConfig{MinutesPerDay: 10}}, history)
+
+	if view := model.View(); !strings.Contains(view, "Streak") || !strings.Contains(view, "Wed") {
+		t.Error("View() should show the streak and the calendar")
+	}
+
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if view := updated.View(); !strings.Contains(view, "Streak") || strings.Contains(view, "Wed") {
+		t.Error("View() in a short terminal should keep the streak and leave out the calendar")
+	}
+}
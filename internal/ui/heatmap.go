@@ -0,0 +1,242 @@
+package ui
+
+import (
+	"fmt"
+	"go-touch/internal/keyboard"
+	"go-touch/internal/types"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// heatmapMetric selects what the dashboard heatmap colours keys by
+type heatmapMetric int
+
+const (
+	heatmapErrors heatmapMetric = iota
+	heatmapLatency
+	heatmapMetricCount
+)
+
+func (h heatmapMetric) String() string {
+	if h == heatmapLatency {
+		return "Latency"
+	}
+	return "Error rate"
+}
+
+// Heat levels from best to worst; heatNone marks keys without data
+const (
+	heatNone = iota - 1
+	heatGood
+	heatFair
+	heatPoor
+)
+
+// Thresholds for the heat levels. Latency is judged relative to the average
+// of all keys so the map highlights bottlenecks at any typing speed.
+const (
+	fairErrorRate   = 0.03
+	poorErrorRate   = 0.08
+	fairLatencyRate = 1.15
+	poorLatencyRate = 1.5
+)
+
+// heatStyles colours keys by heat level
+var heatStyles = map[int]lipgloss.Style{
+	heatNone: DefaultTheme.Muted,
+	heatGood: lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(lipgloss.Color("2")),
+	heatFair: lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(lipgloss.Color("3")),
+	heatPoor: lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(lipgloss.Color("1")),
+}
+
+// historyKeyStats merges the per-character stats of all sessions
+func historyKeyStats(stats types.UserStats) map[string]types.KeyStat {
+	merged := make(map[string]types.KeyStat)
+	for _, session := range stats.Sessions {
+		for char, stat := range session.KeyStats {
+			merged[char] = merged[char].Add(stat)
+		}
+	}
+	return merged
+}
+
+// physicalKeyStats folds per-character stats onto the keys of a layout, so
+// that "a" and "A" count towards the same key. Characters not on the layout are dropped.
+func physicalKeyStats(layout *keyboard.Layout, stats map[string]types.KeyStat) map[keyboard.Key]types.KeyStat {
+	perKey := make(map[keyboard.Key]types.KeyStat)
+	for char, stat := range stats {
+		runes := []rune(char)
+		if len(runes) != 1 {
+			continue
+		}
+		key, _, ok := layout.Find(runes[0])
+		if !ok {
+			continue
+		}
+		perKey[key] = perKey[key].Add(stat)
+	}
+	return perKey
+}
+
+// meanLatency returns the average latency over all presses
+func meanLatency(perKey map[keyboard.Key]types.KeyStat) time.Duration {
+	var total types.KeyStat
+	for _, stat := range perKey {
+		total = total.Add(stat)
+	}
+	return total.MeanLatency()
+}
+
+// heatLevel rates a key by the chosen metric; average is the mean latency of all keys
+func heatLevel(metric heatmapMetric, stat types.KeyStat, average time.Duration) int {
+	if stat.Presses == 0 {
+		return heatNone
+	}
+	var value, fair, poor float64
+	if metric == heatmapLatency {
+		if average <= 0 {
+			return heatNone
+		}
+		value = float64(stat.MeanLatency()) / float64(average)
+		fair, poor = fairLatencyRate, poorLatencyRate
+	} else {
+		value = stat.ErrorRate()
+		fair, poor = fairErrorRate, poorErrorRate
+	}
+	switch {
+	case value >= poor:
+		return heatPoor
+	case value >= fair:
+		return heatFair
+	default:
+		return heatGood
+	}
+}
+
+// renderHeatmap draws the layout with every key coloured by its heat level
+func renderHeatmap(layout *keyboard.Layout, perKey map[keyboard.Key]types.KeyStat, metric heatmapMetric) string {
+	average := meanLatency(perKey)
+	return drawKeyboard(layout, func(key keyboard.Key) lipgloss.Style {
+		return heatStyles[heatLevel(metric, perKey[key], average)]
+	})
+}
+
+// weakestKeys returns up to n keys with the worst value of the chosen metric, worst first
+func weakestKeys(perKey map[keyboard.Key]types.KeyStat, metric heatmapMetric, n int) []keyboard.Key {
+	var keys []keyboard.Key
+	for key, stat := range perKey {
+		if stat.Presses == 0 || (metric == heatmapErrors && stat.Errors == 0) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	value := func(key keyboard.Key) float64 {
+		if metric == heatmapLatency {
+			return float64(perKey[key].MeanLatency())
+		}
+		return perKey[key].ErrorRate()
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if value(keys[i]) != value(keys[j]) {
+			return value(keys[i]) > value(keys[j])
+		}
+		// Stable order for equal values
+		if keys[i].Row != keys[j].Row {
+			return keys[i].Row < keys[j].Row
+		}
+		return keys[i].Col < keys[j].Col
+	})
+
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
+
+// heatmapLegend explains the colours for the chosen metric
+func heatmapLegend(metric heatmapMetric) string {
+	labels := []string{
+		fmt.Sprintf("< %.0f%%", fairErrorRate*100),
+		fmt.Sprintf("< %.0f%%", poorErrorRate*100),
+		fmt.Sprintf("≥ %.0f%%", poorErrorRate*100),
+	}
+	if metric == heatmapLatency {
+		labels = []string{
+			"near average",
+			fmt.Sprintf("%gx slower", fairLatencyRate),
+			fmt.Sprintf("%gx slower", poorLatencyRate),
+		}
+	}
+
+	var parts []string
+	for level, label := range labels {
+		parts = append(parts, heatStyles[level].Render("   ")+" "+DefaultTheme.Muted.Render(label))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// renderHeatmapPanel renders the dashboard's key heatmap section, or nothing
+// when neither this session nor the history has per-key stats
+func (m dashboardModel) renderHeatmapPanel(termWidth int) string {
+	history := historyKeyStats(m.allStats)
+	if len(m.currentSession.KeyStats) == 0 && len(history) == 0 {
+		return ""
+	}
+
+	layout := m.layout()
+
+	scope, stats := "This session", m.currentSession.KeyStats
+	if m.heatmapHistory {
+		scope, stats = "All sessions", history
+	}
+	perKey := physicalKeyStats(layout, stats)
+
+	center := lipgloss.NewStyle().Align(lipgloss.Center).Width(termWidth)
+
+	var s strings.Builder
+	s.WriteString(lipgloss.NewStyle().
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderTop(true).
+		BorderForeground(lipgloss.Color("8")).
+		Padding(0, 2).
+		Align(lipgloss.Center).
+		Width(termWidth - 4).
+		Render(DefaultTheme.Info.Render("Key Heatmap")))
+	s.WriteString("\n\n")
+	s.WriteString(center.Render(fmt.Sprintf("%s · %s", m.heatmapMetric, scope)))
+	s.WriteString("\n\n")
+
+	if len(perKey) == 0 {
+		s.WriteString(center.Render(DefaultTheme.Muted.Render("No key data for " + strings.ToLower(scope))))
+	} else {
+		// Narrow terminals only get the weakest keys
+		if termWidth >= keyboardWidth+4 {
+			// Center the keyboard as a block so the row stagger is kept
+			s.WriteString(center.Render(lipgloss.NewStyle().Align(lipgloss.Left).Render(renderHeatmap(layout, perKey, m.heatmapMetric))))
+			s.WriteString("\n\n")
+			s.WriteString(center.Render(heatmapLegend(m.heatmapMetric)))
+		}
+
+		if weakest := weakestKeys(perKey, m.heatmapMetric, 3); len(weakest) > 0 {
+			var parts []string
+			for _, key := range weakest {
+				stat := perKey[key]
+				if m.heatmapMetric == heatmapLatency {
+					parts = append(parts, fmt.Sprintf("%s %dms (%s)", key.Label(), stat.MeanLatency().Milliseconds(), key.Finger))
+				} else {
+					parts = append(parts, fmt.Sprintf("%s %.0f%% (%s)", key.Label(), stat.ErrorRate()*100, key.Finger))
+				}
+			}
+			s.WriteString("\n")
+			s.WriteString(center.Render(fmt.Sprintf("%s: %s", DefaultTheme.Muted.Render("Weakest keys"), strings.Join(parts, " • "))))
+		}
+	}
+
+	s.WriteString("\n")
+	s.WriteString(center.Render(DefaultTheme.Muted.Render("Tab: error rate/latency • H: this session/all sessions")))
+	return s.String()
+}
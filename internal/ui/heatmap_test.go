@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"go-touch/internal/keyboard"
+	"go-touch/internal/types"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPhysicalKeyStats(t *testing.T) {
+	layout := keyboard.Default()
+	stats := map[string]types.KeyStat{
+		"a": {Presses: 3, Errors: 1},
+		"A": {Presses: 1, Errors: 1},
+		"é": {Presses: 5},
+	}
+
+	perKey := physicalKeyStats(layout, stats)
+
+	key, _, _ := layout.Find('a')
+	if got := perKey[key]; got.Presses != 4 || got.Errors != 2 {
+		t.Errorf("perKey[a] = %+v, want 4 presses and 2 errors", got)
+	}
+	if len(perKey) != 1 {
+		t.Errorf("len(perKey) = %d, want 1 (characters off the layout are dropped)", len(perKey))
+	}
+}
+
+func TestHeatLevel(t *testing.T) {
+	average := 200 * time.Millisecond
+
+	tests := []struct {
+		name   string
+		metric heatmapMetric
+		stat   types.KeyStat
+		want   int
+	}{
+		{"no presses", heatmapErrors, types.KeyStat{}, heatNone},
+		{"accurate key", heatmapErrors, types.KeyStat{Presses: 100, Errors: 1}, heatGood},
+		{"some errors", heatmapErrors, types.KeyStat{Presses: 100, Errors: 5}, heatFair},
+		{"many errors", heatmapErrors, types.KeyStat{Presses: 10, Errors: 2}, heatPoor},
+		{"average speed", heatmapLatency, types.KeyStat{Presses: 2, Latency: 400 * time.Millisecond}, heatGood},
+		{"slow key", heatmapLatency, types.KeyStat{Presses: 1, Latency: 250 * time.Millisecond}, heatFair},
+		{"very slow key", heatmapLatency, types.KeyStat{Presses: 1, Latency: 400 * time.Millisecond}, heatPoor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := heatLevel(tt.metric, tt.stat, average); got != tt.want {
+				t.Errorf("heatLevel() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeakestKeys(t *testing.T) {
+	layout := keyboard.Default()
+	perKey := physicalKeyStats(layout, map[string]types.KeyStat{
+		"p": {Presses: 10, Errors: 3, Latency: time.Second},
+		"f": {Presses: 10, Errors: 1, Latency: 3 * time.Second},
+		"j": {Presses: 10, Errors: 0, Latency: 2 * time.Second},
+	})
+
+	errors := weakestKeys(perKey, heatmapErrors, 3)
+	if len(errors) != 2 || errors[0].Base != 'p' || errors[1].Base != 'f' {
+		t.Errorf("weakestKeys(errors) = %v, want p then f", errors)
+	}
+
+	latency := weakestKeys(perKey, heatmapLatency, 2)
+	if len(latency) != 2 || latency[0].Base != 'f' || latency[1].Base != 'j' {
+		t.Errorf("weakestKeys(latency) = %v, want f then j", latency)
+	}
+}
+
+func TestDashboardModel_Heatmap(t *testing.T) {
+	session := types.TypingSession{
+		WPM:      50,
+		Accuracy: 95,
+		KeyStats: map[string]types.KeyStat{"p": {Presses: 10, Errors: 3}},
+	}
+	model := newDashboardModel(types.Config{}, session, types.UserStats{Sessions: []types.TypingSession{session}})
+	model.width = 100
+
+	view := model.View()
+	if !contains(view, "Key Heatmap") || !contains(view, "Error rate · This session") {
+		t.Error("Dashboard should show the error rate heatmap for this session")
+	}
+	if !contains(view, "right pinky") {
+		t.Error("Dashboard should name the finger of the weakest key")
+	}
+
+	updatedModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	updatedModel, _ = updatedModel.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	if !contains(updatedModel.View(), "Latency · All sessions") {
+		t.Error("Tab and H should switch the heatmap to latency over all sessions")
+	}
+}
+
+func TestDashboardModel_NoHeatmapWithoutKeyStats(t *testing.T) {
+	model := newDashboardModel(types.Config{}, types.TypingSession{WPM: 50}, types.UserStats{})
+	if contains(model.View(), "Key Heatmap") {
+		t.Error("Dashboard should not show a heatmap for sessions without key stats")
+	}
+}
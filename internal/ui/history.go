@@ -0,0 +1,409 @@
+package ui
+
+import (
+	"fmt"
+	"go-touch/internal/stats"
+	"go-touch/internal/types"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// movingAverageWindow is the number of sessions averaged for the trend line
+	movingAverageWindow = 5
+	// historyChartHeight is the number of rows of each chart
+	historyChartHeight = 6
+	// historyListRows is the number of sessions visible in the list at once
+	historyListRows = 8
+)
+
+// historyRange limits the history screen to recent sessions
+type historyRange int
+
+const (
+	range7Days historyRange = iota
+	range30Days
+	rangeAll
+	historyRangeCount
+)
+
+func (r historyRange) String() string {
+	switch r {
+	case range7Days:
+		return "7 days"
+	case range30Days:
+		return "30 days"
+	default:
+		return "All"
+	}
+}
+
+// since returns the start of the range, zero for the whole history
+func (r historyRange) since(now time.Time) time.Time {
+	switch r {
+	case range7Days:
+		return now.AddDate(0, 0, -7)
+	case range30Days:
+		return now.AddDate(0, 0, -30)
+	default:
+		return time.Time{}
+	}
+}
+
+// historyModel shows the recorded sessions with WPM and accuracy charts
+type historyModel struct {
+	config types.Config
+	stats  types.UserStats
+	now    time.Time
+	width  int
+	height int
+
+	rangeSel historyRange
+	sessions []types.TypingSession // sessions in range, oldest first
+	cursor   int                   // selected session, 0 is the newest
+	offset   int                   // first list row shown
+	detail   bool                  // show the selected session instead of the overview
+	quit     bool                  // leave the app instead of returning to the menu
+}
+
+func newHistoryModel(config types.Config, stats types.UserStats, now time.Time) historyModel {
+	m := historyModel{config: config, stats: stats, now: now, rangeSel: range30Days}
+	m.applyRange()
+	return m
+}
+
+// applyRange selects the sessions of the current range and resets the list
+func (m *historyModel) applyRange() {
+	m.sessions = stats.Filter{Since: m.rangeSel.since(m.now)}.Apply(m.stats).Sessions
+	m.cursor = 0
+	m.offset = 0
+	m.detail = false
+}
+
+// selected returns the session under the cursor
+func (m historyModel) selected() (types.TypingSession, bool) {
+	if len(m.sessions) == 0 {
+		return types.TypingSession{}, false
+	}
+	return m.sessions[len(m.sessions)-1-m.cursor], true
+}
+
+func (m historyModel) Init() tea.Cmd {
+	return tea.WindowSize()
+}
+
+func (m historyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		if m.detail {
+			switch msg.String() {
+			case "ctrl+c":
+				m.quit = true
+				return m, tea.Quit
+			case "esc", "enter", "backspace", "q":
+				m.detail = false
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "ctrl+c":
+			m.quit = true
+			return m, tea.Quit
+		case "esc", "q":
+			return m, tea.Quit
+		case "up", "k":
+			m.moveCursor(-1)
+		case "down", "j":
+			m.moveCursor(1)
+		case "pgup":
+			m.moveCursor(-historyListRows)
+		case "pgdown":
+			m.moveCursor(historyListRows)
+		case "tab", "right":
+			m.rangeSel = (m.rangeSel + 1) % historyRangeCount
+			m.applyRange()
+		case "shift+tab", "left":
+			m.rangeSel = (m.rangeSel + historyRangeCount - 1) % historyRangeCount
+			m.applyRange()
+		case "enter":
+			if len(m.sessions) > 0 {
+				m.detail = true
+			}
+		}
+	}
+	return m, nil
+}
+
+// moveCursor moves the selection by delta, scrolling the list to keep it visible
+func (m *historyModel) moveCursor(delta int) {
+	m.cursor = max(0, min(m.cursor+delta, len(m.sessions)-1))
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+historyListRows {
+		m.offset = m.cursor - historyListRows + 1
+	}
+}
+
+func (m historyModel) View() string {
+	termWidth := m.width
+	if termWidth == 0 {
+		termWidth = 80
+	}
+
+	var s strings.Builder
+	s.WriteString(lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("8")).
+		Padding(0, 2).
+		Align(lipgloss.Center).
+		Width(termWidth - 4).
+		Render(DefaultTheme.Title.Render("HISTORY")))
+	s.WriteString("\n\n")
+
+	if session, ok := m.selected(); ok && m.detail {
+		s.WriteString(renderSessionDetail(session, termWidth))
+		s.WriteString("\n\n")
+		s.WriteString(DefaultTheme.Muted.Render("Esc or Enter to go back"))
+		return s.String()
+	}
+
+	s.WriteString(lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(termWidth).
+		Render(m.renderRanges()))
+	s.WriteString("\n\n")
+
+	if len(m.sessions) == 0 {
+		s.WriteString(lipgloss.NewStyle().
+			Align(lipgloss.Center).
+			Width(termWidth).
+			Render(DefaultTheme.Muted.Render("No sessions in this range yet")))
+		s.WriteString("\n\n")
+	} else {
+		wpm := make([]float64, len(m.sessions))
+		accuracy := make([]float64, len(m.sessions))
+		for i, session := range m.sessions {
+			wpm[i] = float64(session.WPM)
+			accuracy[i] = float64(session.Accuracy)
+		}
+		chartWidth := max(10, termWidth-12)
+		s.WriteString(renderChart("WPM", wpm, chartWidth))
+		s.WriteString("\n")
+		s.WriteString(renderChart("Accuracy %", accuracy, chartWidth))
+		s.WriteString(DefaultTheme.Muted.Render(fmt.Sprintf("%s ─ %s   • session  ─ %d-session average",
+			m.sessions[0].Date.Local().Format("Jan 2"),
+			m.sessions[len(m.sessions)-1].Date.Local().Format("Jan 2"), movingAverageWindow)))
+		s.WriteString("\n\n")
+		s.WriteString(m.renderList())
+		s.WriteString("\n")
+	}
+
+	s.WriteString(DefaultTheme.Muted.Render("↑/↓ select • Enter details • Tab/←/→ change range • Esc back to menu"))
+	return s.String()
+}
+
+// renderRanges shows the range options with the current one highlighted
+func (m historyModel) renderRanges() string {
+	tabs := make([]string, historyRangeCount)
+	for r := range historyRangeCount {
+		if r == m.rangeSel {
+			tabs[r] = DefaultTheme.Highlight.Render("[ " + r.String() + " ]")
+		} else {
+			tabs[r] = DefaultTheme.Muted.Render("  " + r.String() + "  ")
+		}
+	}
+	summary := stats.Summarize(m.sessions)
+	return strings.Join(tabs, "  ") + DefaultTheme.Muted.Render(fmt.Sprintf("   %d sessions • Avg %.0f WPM • Best %.0f WPM",
+		summary.Sessions, summary.AvgWPM, summary.BestWPM))
+}
+
+// renderList shows the visible part of the session list, newest first
+func (m historyModel) renderList() string {
+	var s strings.Builder
+	s.WriteString(DefaultTheme.Muted.Render(fmt.Sprintf("  %-16s  %-22s  %6s  %8s  %6s", "Date", "Mode", "WPM", "Accuracy", "Errors")))
+	s.WriteString("\n")
+	end := min(m.offset+historyListRows, len(m.sessions))
+	for row := m.offset; row < end; row++ {
+		session := m.sessions[len(m.sessions)-1-row]
+		line := fmt.Sprintf("%-16s  %-22s  %6.1f  %7.1f%%  %6d",
+			session.Date.Local().Format("2006-01-02 15:04"), SessionLabel(session),
+			session.WPM, session.Accuracy, session.Errors)
+		if row == m.cursor {
+			s.WriteString(DefaultTheme.Highlight.Render("> " + line))
+		} else {
+			s.WriteString("  " + line)
+		}
+		s.WriteString("\n")
+	}
+	if len(m.sessions) > historyListRows {
+		s.WriteString(DefaultTheme.Muted.Render(fmt.Sprintf("  %d-%d of %d", m.offset+1, end, len(m.sessions))))
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+// renderSessionDetail shows everything recorded about one session
+func renderSessionDetail(session types.TypingSession, width int) string {
+	rows := [][2]string{
+		{"Date", session.Date.Local().Format("Monday, 2 January 2006 15:04")},
+		{"Mode", SessionLabel(session)},
+		{"WPM", fmt.Sprintf("%.1f", session.WPM)},
+		{"Accuracy", fmt.Sprintf("%.1f%%", session.Accuracy)},
+		{"Errors", fmt.Sprintf("%d", session.Errors)},
+		{"Duration", formatDuration(session.Duration)},
+	}
+	if session.RawWPM > 0 {
+		rows = append(rows,
+			[2]string{"Gross / Raw WPM", fmt.Sprintf("%.1f / %.1f", session.GrossWPM, session.RawWPM)},
+			[2]string{"Consistency", fmt.Sprintf("%.0f%%", session.Consistency)},
+			[2]string{"Corrected / Uncorrected", fmt.Sprintf("%d / %d", session.CorrectedErrors, session.UncorrectedErrors)})
+	}
+	if session.PausedTime > 0 {
+		rows = append(rows, [2]string{"Paused", formatDuration(session.PausedTime)})
+	}
+	if session.Source != "" {
+		source := session.Source
+		if session.Model != "" {
+			source += fmt.Sprintf(" (%s %s)", session.Provider, session.Model)
+		}
+		rows = append(rows, [2]string{"Source", source})
+	}
+	if session.KeyboardLayout != "" {
+		rows = append(rows, [2]string{"Keyboard", session.KeyboardLayout})
+	}
+	if len(session.MistypedWords) > 0 {
+		words := make([]string, 0, len(session.MistypedWords))
+		for _, word := range session.MistypedWords {
+			words = append(words, word.Target)
+		}
+		rows = append(rows, [2]string{"Mistyped", strings.Join(words, ", ")})
+	}
+
+	var s strings.Builder
+	for _, row := range rows {
+		s.WriteString(fmt.Sprintf("%s %s\n", DefaultTheme.Muted.Render(fmt.Sprintf("%-24s", row[0])), row[1]))
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("8")).
+		Padding(1, 2).
+		Width(min(width-4, 80)).
+		Render(strings.TrimRight(s.String(), "\n"))
+}
+
+// renderChart draws a titled line chart of the values with their moving average
+func renderChart(title string, values []float64, width int) string {
+	var s strings.Builder
+	s.WriteString(DefaultTheme.Info.Render(title))
+	s.WriteString("\n")
+	for _, line := range lineChart(values, width, historyChartHeight) {
+		s.WriteString(line)
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+// lineChart plots the values oldest to newest on a grid of at most width
+// columns and height rows, one dot per column and the moving average drawn
+// over them. Lines are returned top to bottom with the value range on the axis.
+func lineChart(values []float64, width, height int) []string {
+	if len(values) == 0 || width <= 0 || height <= 0 {
+		return nil
+	}
+	points := resample(values, width)
+	trend := resample(movingAverage(values, movingAverageWindow), width)
+
+	lo, hi := points[0], points[0]
+	for _, v := range append(append([]float64(nil), points...), trend...) {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	if hi == lo {
+		lo, hi = lo-1, hi+1
+	}
+	row := func(v float64) int {
+		return height - 1 - int(math.Round((v-lo)/(hi-lo)*float64(height-1)))
+	}
+
+	grid := make([][]string, height)
+	for r := range grid {
+		grid[r] = make([]string, len(points))
+		for c := range grid[r] {
+			grid[r][c] = " "
+		}
+	}
+	for c := range points {
+		grid[row(points[c])][c] = DefaultTheme.Muted.Render("•")
+	}
+	for c := range trend {
+		grid[row(trend[c])][c] = DefaultTheme.Highlight.Render("─")
+	}
+
+	top, bottom := fmt.Sprintf("%.0f", hi), fmt.Sprintf("%.0f", lo)
+	labelWidth := max(len(top), len(bottom))
+	lines := make([]string, height)
+	for r := range grid {
+		label, axis := "", "│"
+		switch r {
+		case 0:
+			label, axis = top, "┤"
+		case height - 1:
+			label, axis = bottom, "┤"
+		}
+		lines[r] = DefaultTheme.Muted.Render(fmt.Sprintf("%*s %s", labelWidth, label, axis)) + strings.Join(grid[r], "")
+	}
+	return lines
+}
+
+// movingAverage returns the trailing average of each value over window values
+func movingAverage(values []float64, window int) []float64 {
+	averages := make([]float64, len(values))
+	var sum float64
+	for i, v := range values {
+		sum += v
+		if i >= window {
+			sum -= values[i-window]
+		}
+		averages[i] = sum / float64(min(i+1, window))
+	}
+	return averages
+}
+
+// resample fits the values into at most width points, averaging neighbouring
+// values when there are more values than columns
+func resample(values []float64, width int) []float64 {
+	if len(values) <= width {
+		return values
+	}
+	points := make([]float64, width)
+	for c := range points {
+		from := c * len(values) / width
+		to := (c + 1) * len(values) / width
+		var sum float64
+		for _, v := range values[from:to] {
+			sum += v
+		}
+		points[c] = sum / float64(to-from)
+	}
+	return points
+}
+
+// showHistory runs the history screen until the user goes back to the menu.
+// It reports whether the user quit the app instead.
+func showHistory(config types.Config, stats types.UserStats) (bool, error) {
+	program := tea.NewProgram(newHistoryModel(config, stats, time.Now()), tea.WithAltScreen())
+	finalModel, err := program.Run()
+	if err != nil {
+		return false, err
+	}
+	return finalModel.(historyModel).quit, nil
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"go-touch/internal/types"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMovingAverage(t *testing.T) {
+	got := movingAverage([]float64{10, 20, 30, 40}, 2)
+	want := []float64{10, 15, 25, 35}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("movingAverage() = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestResample(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		want   []float64
+	}{
+		{"fits", []float64{1, 2, 3}, 5, []float64{1, 2, 3}},
+		{"halved", []float64{1, 3, 5, 7}, 2, []float64{2, 6}},
+		{"uneven", []float64{1, 2, 3, 4, 5}, 2, []float64{1.5, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resample(tt.values, tt.width)
+			if len(got) != len(tt.want) {
+				t.Fatalf("resample() = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("resample() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestLineChart(t *testing.T) {
+	lines := lineChart([]float64{40, 50, 60, 70}, 20, 4)
+	if len(lines) != 4 {
+		t.Fatalf("lineChart() returned %d lines, want 4", len(lines))
+	}
+	if !strings.Contains(lines[0], "70") || !strings.Contains(lines[3], "40") {
+		t.Errorf("lineChart() axis labels = %q ... %q, want 70 and 40", lines[0], lines[3])
+	}
+	// The newest and fastest session is on the top row
+	if !strings.Contains(lines[0], "•") && !strings.Contains(lines[0], "─") {
+		t.Errorf("lineChart() top row = %q, want a point", lines[0])
+	}
+
+	if lines := lineChart(nil, 20, 4); lines != nil {
+		t.Errorf("lineChart(nil) = %v, want nil", lines)
+	}
+	// A flat history still draws
+	if lines := lineChart([]float64{50, 50}, 20, 4); len(lines) != 4 {
+		t.Errorf("lineChart() of equal values returned %d lines, want 4", len(lines))
+	}
+}
+
+func newHistoryTestModel(now time.Time, ages ...time.Duration) historyModel {
+	var stats types.UserStats
+	for i := len(ages) - 1; i >= 0; i-- {
+		stats.Sessions = append(stats.Sessions, types.TypingSession{Date: now.Add(-ages[i]), WPM: float32(40 + i)})
+	}
+	return newHistoryModel(types.Config{}, stats, now)
+}
+
+func TestHistoryModel_Ranges(t *testing.T) {
+	now := time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	m := newHistoryTestModel(now, day, 10*day, 60*day)
+
+	if len(m.sessions) != 2 {
+		t.Errorf("default range has %d sessions, want the 2 from the last 30 days", len(m.sessions))
+	}
+
+	var model tea.Model = m
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := model.(historyModel); got.rangeSel != rangeAll || len(got.sessions) != 3 {
+		t.Errorf("after Tab range = %v with %d sessions, want All with 3", got.rangeSel, len(got.sessions))
+	}
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := model.(historyModel); got.rangeSel != range7Days || len(got.sessions) != 1 {
+		t.Errorf("after second Tab range = %v with %d sessions, want 7 days with 1", got.rangeSel, len(got.sessions))
+	}
+}
+
+func TestHistoryModel_SelectAndDetail(t *testing.T) {
+	now := time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC)
+	ages := make([]time.Duration, 12)
+	for i := range ages {
+		ages[i] = time.Duration(i+1) * time.Hour
+	}
+	m := newHistoryTestModel(now, ages...)
+
+	// The newest session is selected first
+	if session, _ := m.selected(); session.WPM != 40 {
+		t.Errorf("selected() WPM = %v, want the newest session", session.WPM)
+	}
+
+	var model tea.Model = m
+	for i := 0; i < 10; i++ {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	m = model.(historyModel)
+	if m.cursor != 10 || m.offset != 10-historyListRows+1 {
+		t.Errorf("after 10 downs cursor = %d, offset = %d", m.cursor, m.offset)
+	}
+	if session, _ := m.selected(); session.WPM != 50 {
+		t.Errorf("selected() WPM = %v, want 50", session.WPM)
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(historyModel)
+	if !m.detail || !strings.Contains(m.View(), "50.0") {
+		t.Errorf("Enter should show the selected session's details:\n%s", m.View())
+	}
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(historyModel).detail || cmd != nil {
+		t.Error("Esc in the details should return to the list without quitting")
+	}
+	if model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc}); cmd == nil || model.(historyModel).quit {
+		t.Error("Esc in the list should return to the menu")
+	}
+}
+
+func TestHistoryModel_CtrlCQuits(t *testing.T) {
+	now := time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC)
+	list := newHistoryTestModel(now, time.Hour)
+	detail := list
+	detail.detail = true
+
+	for name, m := range map[string]historyModel{"list": list, "detail": detail} {
+		model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+		if cmd == nil || !model.(historyModel).quit {
+			t.Errorf("Ctrl+C in the %s should quit the app", name)
+		}
+	}
+}
+
+func TestHistoryModel_View(t *testing.T) {
+	now := time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC)
+	view := newHistoryTestModel(now, time.Hour, 2*time.Hour).View()
+	for _, want := range []string{"HISTORY", "30 days", "WPM", "Accuracy %", "2 sessions", "average"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q", want)
+		}
+	}
+
+	empty := newHistoryModel(types.Config{}, types.UserStats{}, now).View()
+	if !strings.Contains(empty, "No sessions") {
+		t.Error("View() of an empty history should say there are no sessions")
+	}
+}
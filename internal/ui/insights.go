@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"go-touch/internal/analytics"
+	"go-touch/internal/stats"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderInsights shows the learning curve feedback for sessions typed the same
+// way as this one, empty before any such session is recorded
+func (m dashboardModel) renderInsights(termWidth int) string {
+	comparable := stats.Comparable(m.allStats, m.currentSession)
+	if len(comparable.Sessions) == 0 || m.currentSession.Drill {
+		return ""
+	}
+	insights := analytics.Analyze(comparable.Sessions, float64(m.config.Goals.TargetWPM), time.Now())
+
+	center := lipgloss.NewStyle().Align(lipgloss.Center).Width(termWidth)
+	var s strings.Builder
+	s.WriteString(lipgloss.NewStyle().
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderTop(true).
+		BorderForeground(lipgloss.Color("8")).
+		Padding(0, 2).
+		Align(lipgloss.Center).
+		Width(termWidth - 4).
+		Render(DefaultTheme.Info.Render("Insights")))
+	s.WriteString("\n\n")
+	for i, message := range insights.Messages() {
+		if i > 0 {
+			s.WriteString("\n")
+		}
+		switch {
+		case !insights.Ready:
+			message = DefaultTheme.Muted.Render(message)
+		case i == 0 && (insights.Plateau || insights.Trend <= -0.5):
+			message = DefaultTheme.Warning.Render(message)
+		}
+		s.WriteString(center.Render(message))
+	}
+	return s.String()
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"go-touch/internal/types"
+	"testing"
+	"time"
+)
+
+func TestDashboardModel_Insights(t *testing.T) {
+	now := time.Now()
+	var history types.UserStats
+	for i := 0; i < 12; i++ {
+		history.Sessions = append(history.Sessions, types.TypingSession{
+			Date: now.AddDate(0, 0, i-11), WPM: float32(30 + 2*i), Accuracy: 95, Source: "dummy",
+		})
+	}
+	session := history.Sessions[len(history.Sessions)-1]
+
+	config := types.Config{Goals: types.GoalsConfig{TargetWPM: 200}}
+	model := newDashboardModel(config, session, history)
+	model.width = 120
+	view := model.View()
+	for _, want := range []string{"Insights", "Improving", "200 WPM is out of reach"} {
+		if !contains(view, want) {
+			t.Errorf("Dashboard should show %q", want)
+		}
+	}
+
+	model = newDashboardModel(types.Config{}, session, types.UserStats{Sessions: history.Sessions[:3]})
+	model.width = 120
+	if view := model.View(); !contains(view, "Insights appear after 8 sessions (3 so far)") {
+		t.Error("Dashboard should say when insights will appear")
+	}
+
+	model = newDashboardModel(types.Config{}, session, types.UserStats{})
+	model.width = 120
+	if view := model.View(); contains(view, "Insights") {
+		t.Error("Dashboard should not show insights without history")
+	}
+}
@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"fmt"
+	"go-touch/internal/config"
+	"go-touch/internal/keyboard"
+	"go-touch/internal/types"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// loadKeyboardLayout loads the layout selected by ui.keyboard_layout,
+// falling back to QWERTY with a warning if it cannot be loaded
+func loadKeyboardLayout(cfg types.Config) *keyboard.Layout {
+	configDir, _ := config.GetConfigDir()
+	layout, err := keyboard.Load(cfg.Ui.KeyboardLayout, configDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, using QWERTY\n", err)
+		return keyboard.Default()
+	}
+	return layout
+}
+
+const (
+	// minKeyboardHeight is the terminal height below which the on-screen keyboard is hidden
+	minKeyboardHeight = 24
+	// keyboardWidth is the width of the widest keyboard row
+	keyboardWidth = 58
+	// wrongKeyFlash is how long a mistyped key stays marked on the keyboard
+	wrongKeyFlash = 500 * time.Millisecond
+)
+
+// rowIndent approximates the physical stagger of each row in characters (one key is four)
+var rowIndent = map[int]int{
+	keyboard.RowNumber: 0,
+	keyboard.RowTop:    6,
+	keyboard.RowHome:   7,
+	keyboard.RowBottom: 9,
+	keyboard.RowSpace:  17,
+}
+
+// keyboardVisible reports whether the on-screen keyboard should be drawn.
+// It is hidden when toggled off and on terminals too small to fit it.
+func (m sessionModel) keyboardVisible() bool {
+	if !m.showKeyboard {
+		return false
+	}
+	// Size 0 means no WindowSizeMsg arrived yet
+	if m.height > 0 && m.height < minKeyboardHeight {
+		return false
+	}
+	if m.width > 0 && m.width < keyboardWidth+4 {
+		return false
+	}
+	return true
+}
+
+// nextChar returns the character the user is expected to type next
+func (m sessionModel) nextChar() (rune, bool) {
+	if len(m.typedText) >= len(m.text) {
+		return 0, false
+	}
+	return rune(m.text[len(m.typedText)]), true
+}
+
+// keyboardLayout returns the active layout, QWERTY if none was loaded
+func (m sessionModel) keyboardLayout() *keyboard.Layout {
+	if m.keyboard == nil {
+		return keyboard.Default()
+	}
+	return m.keyboard
+}
+
+// renderKeyboard draws the keyboard with the next key highlighted, the keys of
+// its finger brightened and a recently mistyped key marked in red
+func (m sessionModel) renderKeyboard() string {
+	layout := m.keyboardLayout()
+
+	next, hasNext := m.nextChar()
+	nextKey, shifted, onLayout := keyboard.Key{}, false, false
+	if hasNext {
+		nextKey, shifted, onLayout = layout.Find(next)
+	}
+
+	wrongKey, hasWrong := keyboard.Key{}, false
+	if !m.wrongKeyTime.IsZero() && time.Since(m.wrongKeyTime) < wrongKeyFlash {
+		wrongKey, _, hasWrong = layout.Find(m.wrongKey)
+	}
+	wrongStyle := DefaultTheme.Incorrect.Reverse(true)
+
+	diagram := drawKeyboard(layout, func(key keyboard.Key) lipgloss.Style {
+		switch {
+		case hasWrong && key == wrongKey:
+			return wrongStyle
+		case onLayout && key == nextKey:
+			return DefaultTheme.Current
+		case onLayout && key.Finger == nextKey.Finger:
+			return DefaultTheme.Normal
+		default:
+			return DefaultTheme.Muted
+		}
+	})
+
+	return diagram + "\n\n" + DefaultTheme.Muted.Render(fingerHint(next, hasNext, nextKey, shifted, onLayout))
+}
+
+// drawKeyboard renders the rows of a layout with each key in the style chosen by keyStyle
+func drawKeyboard(layout *keyboard.Layout, keyStyle func(keyboard.Key) lipgloss.Style) string {
+	var result strings.Builder
+	for r, row := range layout.Rows {
+		if r > 0 {
+			result.WriteString("\n")
+		}
+		indent := rowIndent[row[0].Row]
+		// ISO boards have a narrow left shift with an extra key next to it
+		if row[0].Row == keyboard.RowBottom && len(row) > 10 {
+			indent = 5
+		}
+		result.WriteString(strings.Repeat(" ", indent))
+
+		for c, key := range row {
+			if c > 0 {
+				result.WriteString(" ")
+			}
+			label := " " + key.Label() + " "
+			if key.Base == ' ' {
+				label = fmt.Sprintf("%-23s", "")
+			}
+			result.WriteString(keyStyle(key).Render(label))
+		}
+	}
+	return result.String()
+}
+
+// fingerHint describes which finger types the next character, e.g. "Next: F — left index, shift with right pinky"
+func fingerHint(next rune, hasNext bool, key keyboard.Key, shifted bool, onLayout bool) string {
+	if !hasNext {
+		return ""
+	}
+	if !onLayout {
+		return fmt.Sprintf("Next: %c — not on this layout", next)
+	}
+	label := string(next)
+	if next == ' ' {
+		label = "space"
+	}
+	hint := fmt.Sprintf("Next: %s — %s", label, key.Finger)
+	if shifted {
+		// Shift is pressed by the pinky of the opposite hand
+		shiftFinger := keyboard.LeftPinky
+		if key.Finger.Hand() == keyboard.LeftHand {
+			shiftFinger = keyboard.RightPinky
+		}
+		hint += fmt.Sprintf(", shift with %s", shiftFinger)
+	}
+	return hint
+}
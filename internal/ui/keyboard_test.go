@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"go-touch/internal/keyboard"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFingerHint(t *testing.T) {
+	layout := keyboard.Default()
+
+	tests := []struct {
+		next rune
+		want string
+	}{
+		{'f', "Next: f — left index"},
+		{'F', "Next: F — left index, shift with right pinky"},
+		{'P', "Next: P — right pinky, shift with left pinky"},
+		{' ', "Next: space — thumb"},
+		{'é', "Next: é — not on this layout"},
+	}
+
+	for _, tt := range tests {
+		key, shifted, ok := layout.Find(tt.next)
+		if got := fingerHint(tt.next, true, key, shifted, ok); got != tt.want {
+			t.Errorf("fingerHint(%q) = %q, want %q", tt.next, got, tt.want)
+		}
+	}
+
+	if got := fingerHint(0, false, keyboard.Key{}, false, false); got != "" {
+		t.Errorf("fingerHint() at end of text = %q, want empty", got)
+	}
+}
+
+func TestKeyboardVisible(t *testing.T) {
+	tests := []struct {
+		name   string
+		show   bool
+		width  int
+		height int
+		want   bool
+	}{
+		{"toggled off", false, 100, 40, false},
+		{"large terminal", true, 100, 40, true},
+		{"size unknown", true, 0, 0, true},
+		{"short terminal", true, 100, minKeyboardHeight - 1, false},
+		{"narrow terminal", true, 40, 40, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := sessionModel{showKeyboard: tt.show, width: tt.width, height: tt.height}
+			if got := model.keyboardVisible(); got != tt.want {
+				t.Errorf("keyboardVisible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionModel_KeyboardToggle(t *testing.T) {
+	model := newModeTestModel("", "test text", "te")
+	model.showKeyboard = true
+	model.width = 100
+	model.height = 40
+
+	if !contains(model.View(), "Next: s — left ring") {
+		t.Error("View should show the finger hint for the next key")
+	}
+
+	updatedModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	m := updatedModel.(sessionModel)
+	if m.showKeyboard {
+		t.Error("CTRL-K should hide the keyboard")
+	}
+	if contains(m.View(), "Next: s") {
+		t.Error("View should not show the keyboard after toggling it off")
+	}
+}
+
+func TestSessionModel_TypoMarksWrongKey(t *testing.T) {
+	model := newModeTestModel("", "test text", "te")
+
+	updatedModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m := updatedModel.(sessionModel)
+
+	if m.wrongKey != 'd' {
+		t.Errorf("wrongKey = %q, want %q", m.wrongKey, 'd')
+	}
+	if time.Since(m.wrongKeyTime) > wrongKeyFlash {
+		t.Error("wrongKeyTime should be set to the time of the typo")
+	}
+}
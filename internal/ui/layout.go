@@ -0,0 +1,230 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Text layouts selectable via ui.layout
+const (
+	layoutLine      = "line"      // Single horizontally scrolling line centred on the cursor
+	layoutParagraph = "paragraph" // Word-wrapped lines scrolling line by line
+)
+
+const (
+	minParagraphLines     = 3
+	maxParagraphLines     = 5
+	defaultParagraphLines = 3
+)
+
+// lineSpan is a wrapped line as a half-open range [start, end) of text offsets
+type lineSpan struct {
+	start int
+	end   int
+}
+
+// isFlashing reports whether the typo flash effect is active
+func (m sessionModel) isFlashing() bool {
+	return !m.typoFlashTime.IsZero() && time.Since(m.typoFlashTime) < time.Duration(m.config.Ui.TypoFlashDurationMs)*time.Millisecond
+}
+
+// renderChar styles the character at index i by its typing status.
+// pace is the pace caret position (-1 when hidden) and upcoming the style for
+// characters not typed yet.
+func (m sessionModel) renderChar(i int, flashing bool, pace int, upcoming lipgloss.Style) string {
+	charStr := string(m.text[i])
+
+	// If flash effect is active, render all text in red
+	if flashing {
+		return DefaultTheme.Incorrect.Render(charStr) // Red flash
+	}
+	if i == pace && i != len(m.typedText) {
+		return DefaultTheme.PaceCaret.Render(charStr)
+	}
+	if i < len(m.typedText) {
+		// Character has been typed
+		if m.typedText[i] == m.text[i] {
+			return DefaultTheme.Correct.Render(charStr) // Green
+		}
+		return DefaultTheme.Incorrect.Render(charStr) // Red
+	}
+	if i == len(m.typedText) {
+		// Current cursor position
+		return DefaultTheme.Current.Render(charStr) // Yellow/highlighted
+	}
+	// Not yet typed
+	return upcoming.Render(charStr)
+}
+
+// renderLine renders the text as a single line scrolled to keep the cursor centred
+func (m sessionModel) renderLine(terminalWidth int) string {
+	var result strings.Builder
+
+	// Reserve space for margins
+	displayWidth := terminalWidth - 4
+
+	// Current cursor position (next character to type)
+	cursorPos := len(m.typedText)
+
+	// Calculate center position
+	centerPos := displayWidth / 2
+
+	// Calculate viewport window
+	var viewportStart, viewportEnd int
+
+	textLen := len(m.text)
+
+	// If text is shorter than display width, no scrolling needed
+	if textLen <= displayWidth {
+		viewportStart = 0
+		viewportEnd = textLen
+	} else {
+		// Calculate viewport to keep cursor centered
+		viewportStart = cursorPos - centerPos
+		viewportEnd = viewportStart + displayWidth
+
+		// Adjust if we're at the beginning
+		if viewportStart < 0 {
+			viewportStart = 0
+			viewportEnd = displayWidth
+		}
+
+		// Clamp viewport end to text length without breaking center alignment
+		if viewportEnd > textLen {
+			viewportEnd = textLen
+		}
+	}
+
+	flashing := m.isFlashing()
+	pace := m.pacePosition(time.Now())
+
+	// Render visible characters
+	// Note: cursor position on screen = (cursorPos - viewportStart)
+	// The viewport calculation already handles centering
+	for i := viewportStart; i < viewportEnd; i++ {
+		// Skip newlines and tabs (as per user's notes)
+		if m.text[i] == '\n' || m.text[i] == '\t' {
+			continue
+		}
+		result.WriteString(m.renderChar(i, flashing, pace, DefaultTheme.Normal))
+	}
+
+	return result.String()
+}
+
+// renderParagraph renders the text word-wrapped to the terminal width, showing a
+// few lines around the active one and scrolling a line at a time
+func (m sessionModel) renderParagraph(terminalWidth int) string {
+	// Reserve space for margins and the active line marker
+	lines := wrapLines(m.text, terminalWidth-6)
+	if len(lines) == 0 {
+		return ""
+	}
+
+	active := activeLine(lines, len(m.typedText))
+	visible := m.paragraphLines()
+
+	// Keep one line of context above the active line once typing moves past the first line
+	first := active - 1
+	if first < 0 {
+		first = 0
+	}
+	last := first + visible
+	if last > len(lines) {
+		last = len(lines)
+	}
+
+	flashing := m.isFlashing()
+	pace := m.pacePosition(time.Now())
+
+	var result strings.Builder
+	for l := first; l < last; l++ {
+		upcoming := DefaultTheme.Muted
+		if l == active {
+			upcoming = DefaultTheme.Normal
+			result.WriteString(DefaultTheme.Highlight.Render("› "))
+		} else {
+			result.WriteString("  ")
+		}
+		for i := lines[l].start; i < lines[l].end; i++ {
+			if m.text[i] == '\n' || m.text[i] == '\t' {
+				continue
+			}
+			result.WriteString(m.renderChar(i, flashing, pace, upcoming))
+		}
+		if l < last-1 {
+			result.WriteString("\n")
+		}
+	}
+
+	return result.String()
+}
+
+// paragraphLines returns the configured number of visible lines, clamped to 3-5
+func (m sessionModel) paragraphLines() int {
+	lines := m.config.Ui.LayoutLines
+	if lines == 0 {
+		return defaultParagraphLines
+	}
+	if lines < minParagraphLines {
+		return minParagraphLines
+	}
+	if lines > maxParagraphLines {
+		return maxParagraphLines
+	}
+	return lines
+}
+
+// wrapLines splits text into lines of at most width characters, breaking after
+// spaces where possible. Each line keeps its trailing space so offsets stay contiguous.
+func wrapLines(text string, width int) []lineSpan {
+	if width < 1 {
+		width = 1
+	}
+
+	var lines []lineSpan
+	start := 0
+	for start < len(text) {
+		end := start
+		lastBreak := -1
+		for end < len(text) && end-start < width {
+			if text[end] == '\n' {
+				break
+			}
+			if text[end] == ' ' {
+				lastBreak = end + 1
+			}
+			end++
+		}
+
+		switch {
+		case end < len(text) && text[end] == '\n':
+			// Forced break: the newline belongs to this line
+			end++
+		case end < len(text) && text[end] == ' ':
+			// Line filled exactly before a space: keep the space on this line
+			end++
+		case end < len(text) && lastBreak > start:
+			// Break after the last space that fits
+			end = lastBreak
+		}
+		// Otherwise a single word longer than the width is hard broken
+
+		lines = append(lines, lineSpan{start: start, end: end})
+		start = end
+	}
+
+	return lines
+}
+
+// activeLine returns the index of the line containing the cursor
+func activeLine(lines []lineSpan, cursor int) int {
+	for i, line := range lines {
+		if cursor < line.end {
+			return i
+		}
+	}
+	return len(lines) - 1
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"go-touch/internal/types"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrapLines(t *testing.T) {
+	text := "the quick brown fox jumps over the lazy dog"
+	lines := wrapLines(text, 15)
+
+	// Lines must cover the text contiguously
+	pos := 0
+	for i, line := range lines {
+		if line.start != pos {
+			t.Fatalf("line %d starts at %d, want %d", i, line.start, pos)
+		}
+		if visible := strings.TrimRight(text[line.start:line.end], " "); len(visible) > 15 {
+			t.Errorf("line %d %q is wider than 15", i, visible)
+		}
+		pos = line.end
+	}
+	if pos != len(text) {
+		t.Errorf("lines end at %d, want %d", pos, len(text))
+	}
+
+	want := []string{"the quick brown ", "fox jumps over ", "the lazy dog"}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d", len(lines), len(want))
+	}
+	for i, line := range lines {
+		if got := text[line.start:line.end]; got != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestWrapLines_LongWordAndNewline(t *testing.T) {
+	lines := wrapLines("abcdefghij\nxy", 4)
+	var got []string
+	for _, line := range lines {
+		got = append(got, "abcdefghij\nxy"[line.start:line.end])
+	}
+	want := []string{"abcd", "efgh", "ij\n", "xy"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("wrapLines() = %q, want %q", got, want)
+	}
+}
+
+func TestActiveLine(t *testing.T) {
+	lines := []lineSpan{{0, 10}, {10, 20}, {20, 25}}
+	tests := []struct {
+		cursor int
+		want   int
+	}{
+		{0, 0},
+		{9, 0},
+		{10, 1},
+		{24, 2},
+		{25, 2}, // cursor past the end stays on the last line
+	}
+	for _, tt := range tests {
+		if got := activeLine(lines, tt.cursor); got != tt.want {
+			t.Errorf("activeLine(%d) = %d, want %d", tt.cursor, got, tt.want)
+		}
+	}
+}
+
+func TestParagraphLines(t *testing.T) {
+	tests := []struct {
+		configured int
+		want       int
+	}{
+		{0, 3},
+		{1, 3},
+		{4, 4},
+		{9, 5},
+	}
+	for _, tt := range tests {
+		model := sessionModel{config: types.Config{Ui: types.UiConfig{LayoutLines: tt.configured}}}
+		if got := model.paragraphLines(); got != tt.want {
+			t.Errorf("paragraphLines() with %d = %d, want %d", tt.configured, got, tt.want)
+		}
+	}
+}
+
+func TestSessionModel_View_ParagraphLayout(t *testing.T) {
+	text := strings.Repeat("lorem ipsum dolor sit amet ", 20)
+	model := sessionModel{
+		text:            text,
+		typedText:       text[:100],
+		hasStarted:      true,
+		width:           40,
+		height:          24,
+		startTime:       time.Now(),
+		lastKeyTime:     time.Now(),
+		sessionDuration: time.Minute,
+		config: types.Config{
+			Ui: types.UiConfig{Layout: layoutParagraph, LayoutLines: 3},
+		},
+	}
+
+	rendered := model.renderParagraph(model.width)
+	lines := strings.Split(rendered, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("paragraph layout rendered %d lines, want 3", len(lines))
+	}
+	if !contains(lines[1], "›") {
+		t.Errorf("active line should be marked, got %q", lines[1])
+	}
+
+	if !contains(model.View(), "›") {
+		t.Error("View should use the paragraph layout when configured")
+	}
+}
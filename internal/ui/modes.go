@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"fmt"
+	"go-touch/internal/stats"
+	"go-touch/internal/types"
+	"strings"
+)
+
+// testModes lists the selectable modes in the order they cycle on the configuration screen
+var testModes = []types.TestMode{
+	types.ModeTime,
+	types.ModeWords,
+	types.ModeText,
+	types.ModeZen,
+	types.ModeSuddenDeath,
+}
+
+// wordCountOptions are the selectable targets for words mode
+var wordCountOptions = []int{10, 25, 50, 100}
+
+// modeLabel returns a human readable name for a test mode
+func modeLabel(mode types.TestMode) string {
+	switch mode {
+	case types.ModeTime, "":
+		return "Time"
+	case types.ModeWords:
+		return "Words"
+	case types.ModeText:
+		return "Complete Text"
+	case types.ModeZen:
+		return "Zen"
+	case types.ModeSuddenDeath:
+		return "Sudden Death"
+	default:
+		return string(mode)
+	}
+}
+
+// SessionLabel describes a session's mode including its mode-specific setting
+func SessionLabel(session types.TypingSession) string {
+	if session.Drill {
+		return "Drill"
+	}
+	mode := session.SessionMode()
+	if mode == types.ModeWords && session.WordCount > 0 {
+		return fmt.Sprintf("%s (%d)", modeLabel(mode), session.WordCount)
+	}
+	if mode == types.ModeTime && session.TargetDuration > 0 {
+		return fmt.Sprintf("%s (%s)", modeLabel(mode), durationLabel(session.TargetDuration))
+	}
+	return modeLabel(mode)
+}
+
+// cycleMode returns the mode delta steps away from current, wrapping around
+func cycleMode(current types.TestMode, delta int) types.TestMode {
+	index := 0
+	for i, mode := range testModes {
+		if mode == current {
+			index = i
+			break
+		}
+	}
+	index = (index + delta + len(testModes)) % len(testModes)
+	return testModes[index]
+}
+
+// cycleWordCount returns the word count option delta steps away from current, clamped to the option range
+func cycleWordCount(current int, delta int) int {
+	index := 0
+	for i, count := range wordCountOptions {
+		if count == current {
+			index = i
+			break
+		}
+	}
+	index += delta
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(wordCountOptions) {
+		index = len(wordCountOptions) - 1
+	}
+	return wordCountOptions[index]
+}
+
+// activeMode returns the selected test mode, defaulting to time mode
+func (m sessionModel) activeMode() types.TestMode {
+	if m.mode == "" {
+		return types.ModeTime
+	}
+	return m.mode
+}
+
+// targetWordCount returns the word goal for words mode
+func (m sessionModel) targetWordCount() int {
+	if m.wordCount <= 0 {
+		return wordCountOptions[0]
+	}
+	return m.wordCount
+}
+
+// wordsTyped counts the target words whose last character has been typed
+func (m sessionModel) wordsTyped() int {
+	typedLen := len(m.typedText)
+	count := 0
+	inWord := false
+	for i := 0; i < len(m.text); i++ {
+		isSpace := m.text[i] == ' ' || m.text[i] == '\n' || m.text[i] == '\t'
+		if !isSpace {
+			inWord = true
+			continue
+		}
+		if inWord {
+			if i > typedLen {
+				return count
+			}
+			count++
+			inWord = false
+		}
+	}
+	if inWord && typedLen >= len(m.text) {
+		count++
+	}
+	return count
+}
+
+// fillWords repeats the text of a source that cannot generate more until it
+// holds the target word count, so a words session never ends short of it
+func (m *sessionModel) fillWords() {
+	base := strings.TrimSpace(m.text)
+	if m.activeMode() != types.ModeWords || m.continuesText() || base == "" {
+		return
+	}
+	for len(strings.Fields(m.text)) < m.targetWordCount() {
+		m.text = strings.TrimRight(m.text, " \n\t") + " " + base
+	}
+	m.currentSentenceEndPos = len(m.text)
+	m.targetWords = strings.Fields(m.text)
+}
+
+// continuesText reports whether the session keeps requesting more text when the current text runs out
+func (m sessionModel) continuesText() bool {
+	return m.isAdaptiveSource && m.activeMode() != types.ModeText
+}
+
+// personalBest compares the dashboard's session with the best comparable
+// session recorded before it. It returns that previous best, whether there
+// was one, and whether the session beat it; the first run of its kind sets
+// a best without beating one.
+func (m dashboardModel) personalBest() (previous float32, hadBest, isNew bool) {
+	previous, hadBest = stats.PreviousBest(m.allStats, m.currentSession)
+	return previous, hadBest, hadBest && m.currentSession.WPM > previous
+}
@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"go-touch/internal/types"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCycleMode(t *testing.T) {
+	if got := cycleMode(types.ModeTime, 1); got != types.ModeWords {
+		t.Errorf("cycleMode(time, 1) = %q, want %q", got, types.ModeWords)
+	}
+	if got := cycleMode(types.ModeTime, -1); got != types.ModeSuddenDeath {
+		t.Errorf("cycleMode(time, -1) = %q, want %q", got, types.ModeSuddenDeath)
+	}
+}
+
+func TestCycleWordCount(t *testing.T) {
+	if got := cycleWordCount(25, 1); got != 50 {
+		t.Errorf("cycleWordCount(25, 1) = %d, want 50", got)
+	}
+	if got := cycleWordCount(100, 1); got != 100 {
+		t.Errorf("cycleWordCount(100, 1) = %d, want 100", got)
+	}
+	if got := cycleWordCount(10, -1); got != 10 {
+		t.Errorf("cycleWordCount(10, -1) = %d, want 10", got)
+	}
+}
+
+func TestWordsTyped(t *testing.T) {
+	tests := []struct {
+		typed string
+		want  int
+	}{
+		{"", 0},
+		{"on", 0},
+		{"one", 1},
+		{"one ", 1},
+		{"one tw", 1},
+		{"one two thr", 2},
+		{"one two three", 3},
+	}
+
+	for _, tt := range tests {
+		model := sessionModel{text: "one two three", typedText: tt.typed}
+		if got := model.wordsTyped(); got != tt.want {
+			t.Errorf("wordsTyped() with %q = %d, want %d", tt.typed, got, tt.want)
+		}
+	}
+}
+
+func newModeTestModel(mode types.TestMode, text, typed string) sessionModel {
+	return sessionModel{
+		text:                  text,
+		typedText:             typed,
+		hasStarted:            true,
+		startTime:             time.Now(),
+		lastKeyTime:           time.Now(),
+		wordsWithErrors:       map[int]bool{},
+		currentProblemWords:   []string{},
+		targetWords:           []string{},
+		currentSentenceEndPos: len(text),
+		mode:                  mode,
+	}
+}
+
+func TestSessionModel_SuddenDeathEndsOnError(t *testing.T) {
+	model := newModeTestModel(types.ModeSuddenDeath, "test text", "te")
+
+	updatedModel, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m := updatedModel.(sessionModel)
+
+	if !m.completed {
+		t.Error("Sudden death should complete on the first error")
+	}
+	if cmd == nil {
+		t.Error("Sudden death should return quit command on error")
+	}
+}
+
+func TestSessionModel_WordsModeEndsAtTarget(t *testing.T) {
+	model := newModeTestModel(types.ModeWords, "aa bb cc dd", "aa b")
+	model.wordCount = 2
+
+	updatedModel, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	m := updatedModel.(sessionModel)
+
+	if !m.completed {
+		t.Error("Words mode should complete once the target word count is typed")
+	}
+	if cmd == nil {
+		t.Error("Words mode should return quit command at target")
+	}
+}
+
+func TestSessionModel_WordsModeRepeatsShortText(t *testing.T) {
+	model := newModeTestModel(types.ModeWords, "aa bb cc\n", "")
+	model.hasStarted = false
+	model.wordCount = 10
+	model.begin(time.Now())
+
+	if got := len(strings.Fields(model.text)); got < 10 {
+		t.Fatalf("words in text = %d, want at least 10", got)
+	}
+	if model.currentSentenceEndPos != len(model.text) || len(model.targetWords) != len(strings.Fields(model.text)) {
+		t.Error("begin() should track the repeated text")
+	}
+
+	// Typing the original text no longer ends the session
+	m := pressKeys(model, "aa bb cc ")
+	if m.completed {
+		t.Error("Words mode should not complete before the target word count")
+	}
+}
+
+func TestSessionModel_NonTimeModesIgnoreExpiry(t *testing.T) {
+	for _, mode := range []types.TestMode{types.ModeWords, types.ModeText, types.ModeZen, types.ModeSuddenDeath} {
+		model := newModeTestModel(mode, "test text", "test")
+		model.startTime = time.Now().Add(-2 * time.Minute)
+		model.sessionDuration = time.Minute
+
+		updatedModel, _ := model.Update(tickMsg(time.Now()))
+		if updatedModel.(sessionModel).completed {
+			t.Errorf("Mode %q should not complete on duration expiry", mode)
+		}
+	}
+}
+
+func TestSessionModel_ZenEscFinishes(t *testing.T) {
+	model := newModeTestModel(types.ModeZen, "test text", "test")
+
+	updatedModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m := updatedModel.(sessionModel)
+
+	if !m.completed || m.quit {
+		t.Errorf("ESC in zen mode should finish the session, got completed=%v quit=%v", m.completed, m.quit)
+	}
+}
+
+func TestSessionModel_LeftRightChangesMode(t *testing.T) {
+	model := sessionModel{text: "test", hasStarted: false}
+
+	updatedModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m := updatedModel.(sessionModel)
+	if m.activeMode() != types.ModeWords {
+		t.Errorf("After right key, mode = %q, want %q", m.activeMode(), types.ModeWords)
+	}
+
+	updatedModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = updatedModel.(sessionModel)
+	if m.targetWordCount() != 25 {
+		t.Errorf("After up key in words mode, wordCount = %d, want 25", m.targetWordCount())
+	}
+
+	if !contains(m.View(), "25 words") {
+		t.Error("View should show the selected word count")
+	}
+}
+
+func TestDashboardModel_PersonalBest(t *testing.T) {
+	at := func(minute int) time.Time { return time.Date(2025, 5, 12, 9, minute, 0, 0, time.UTC) }
+	previous := types.TypingSession{Date: at(0), WPM: 60, Mode: types.ModeTime, TargetDuration: time.Minute, Source: "llm"}
+	sprint := types.TypingSession{Date: at(5), WPM: 95, Mode: types.ModeTime, TargetDuration: 15 * time.Second, Source: "llm"}
+
+	tests := []struct {
+		name      string
+		wpm       float32
+		wantTitle string
+		wantLine  string
+	}{
+		{"new best", 72.5, "NEW PERSONAL BEST!", "+12.5 WPM"},
+		{"below best", 55, "SESSION COMPLETE!", "(-5.0)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := types.TypingSession{Date: at(10), WPM: tt.wpm, Mode: types.ModeTime, TargetDuration: time.Minute, Source: "llm"}
+			history := types.UserStats{Sessions: []types.TypingSession{previous, sprint, session}}
+			view := newDashboardModel(types.Config{}, session, history).View()
+			if !contains(view, tt.wantTitle) || !contains(view, tt.wantLine) {
+				t.Errorf("View() missing %q or %q", tt.wantTitle, tt.wantLine)
+			}
+		})
+	}
+
+	// The first 10-minute run does not compete with the sprint
+	long := types.TypingSession{Date: at(10), WPM: 50, Mode: types.ModeTime, TargetDuration: 10 * time.Minute, Source: "llm"}
+	view := newDashboardModel(types.Config{}, long, types.UserStats{Sessions: []types.TypingSession{sprint, long}}).View()
+	if !contains(view, "First run of its kind") || contains(view, "NEW PERSONAL BEST") {
+		t.Error("View() should mark the first run of a duration without celebrating")
+	}
+}
@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-touch/internal/metrics"
+	"go-touch/internal/sources"
+	"go-touch/internal/stats"
+	"go-touch/internal/types"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// slowNGramLimit is how many slow n-grams the dashboard lists and generation targets
+	slowNGramLimit = 5
+	// minNGramSamples is how often an n-gram must occur in a session to be rated
+	minNGramSamples = 2
+	// minRollingSamples is how often an n-gram must occur across sessions to be rated
+	minRollingSamples = 5
+)
+
+// ngramStatsPath returns the rolling n-gram stats file, stored next to the session stats
+func ngramStatsPath(config types.Config) string {
+	return filepath.Join(filepath.Dir(config.Stats.FileDir), "ngram_stats.json")
+}
+
+// getNGramStats loads the rolling n-gram stats, returning empty stats if the file does not exist
+func getNGramStats(config types.Config) (types.NGramStats, error) {
+	var ngrams types.NGramStats
+	path := ngramStatsPath(config)
+	err := stats.WithLock(path, func() error {
+		var err error
+		ngrams, err = readNGramStats(path)
+		return err
+	})
+	return ngrams, err
+}
+
+// updateNGramStats folds a session's timings into the rolling n-gram stats at
+// path and returns the result. It holds the lock from read to write, so
+// timings saved meanwhile by another instance are kept. A damaged file starts
+// over, as the warning on load says.
+func updateNGramStats(path string, update func(ngrams *types.NGramStats)) (types.NGramStats, error) {
+	var ngrams types.NGramStats
+	err := stats.WithLock(path, func() error {
+		var err error
+		ngrams, err = readNGramStats(path)
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+			ngrams, err = types.NGramStats{}, nil
+		}
+		if err != nil {
+			return err
+		}
+		update(&ngrams)
+
+		data, err := json.MarshalIndent(ngrams, "", "  ")
+		if err != nil {
+			return err
+		}
+		return stats.WriteFileAtomic(path, data, 0644)
+	})
+	return ngrams, err
+}
+
+// readNGramStats reads the rolling n-gram stats at path; the caller holds the lock
+func readNGramStats(path string) (types.NGramStats, error) {
+	var ngrams types.NGramStats
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) || (err == nil && len(strings.TrimSpace(string(data))) == 0) {
+		return ngrams, nil
+	}
+	if err != nil {
+		return ngrams, err
+	}
+	err = json.Unmarshal(data, &ngrams)
+	return ngrams, err
+}
+
+// sessionNGrams measures the bigram and trigram transition times typed so far
+func (m sessionModel) sessionNGrams() (bigrams, trigrams map[string]types.NGramStat) {
+	return metrics.NGrams(m.keystrokes, 2), metrics.NGrams(m.keystrokes, 3)
+}
+
+// slowNGrams returns the n-grams to practise: the slowest of this session
+// first, then the slowest across earlier sessions
+func (m sessionModel) slowNGrams() []string {
+	bigrams, trigrams := m.sessionNGrams()
+	var ngrams []string
+	seen := make(map[string]bool)
+	add := func(ngram string) {
+		if !seen[ngram] && len(ngrams) < slowNGramLimit {
+			seen[ngram] = true
+			ngrams = append(ngrams, ngram)
+		}
+	}
+
+	for _, slow := range metrics.SlowNGrams(mergeNGrams(bigrams, trigrams), "", slowNGramLimit, minNGramSamples) {
+		add(slow.NGram)
+	}
+
+	for _, ngram := range rollingSlowNGrams(m.ngramHistory) {
+		add(ngram)
+	}
+	return ngrams
+}
+
+// rollingSlowNGrams returns the slowest n-grams across earlier sessions, with
+// bigrams and trigrams ranked together per transition
+func rollingSlowNGrams(history types.NGramStats) []string {
+	rolling := append(types.Slowest(history.Trigrams, slowNGramLimit, minRollingSamples),
+		types.Slowest(history.Bigrams, slowNGramLimit, minRollingSamples)...)
+	mean := func(ngram string) time.Duration {
+		stat := history.Bigrams[ngram]
+		if utf8.RuneCountInString(ngram) == 3 {
+			stat = history.Trigrams[ngram]
+		}
+		return types.PerTransition(ngram, stat.Mean)
+	}
+	sort.SliceStable(rolling, func(i, j int) bool { return mean(rolling[i]) > mean(rolling[j]) })
+	return rolling
+}
+
+// slowNGramWords picks a practice word for each of the slowest n-grams across
+// sessions, so text from a source that cannot adapt still works on them
+func slowNGramWords(history types.NGramStats, rng *rand.Rand) []string {
+	var words []string
+	rolling := rollingSlowNGrams(history)
+	for _, ngram := range rolling[:min(len(rolling), slowNGramLimit)] {
+		if samples := sources.SampleWords(ngram, 5); len(samples) > 0 {
+			words = append(words, samples[rng.Intn(len(samples))])
+		}
+	}
+	return words
+}
+
+// mergeNGrams combines n-gram maps of different lengths into one
+func mergeNGrams(maps ...map[string]types.NGramStat) map[string]types.NGramStat {
+	merged := make(map[string]types.NGramStat)
+	for _, stats := range maps {
+		for ngram, stat := range stats {
+			merged[ngram] = merged[ngram].Add(stat)
+		}
+	}
+	return merged
+}
+
+// recordNGrams stores the session's slowest n-grams on the session record and
+// folds all of its n-gram timings into the rolling stats file, leaving the
+// merged stats in rolling. Without a file only rolling is updated.
+func (m sessionModel) recordNGrams(session *types.TypingSession, rolling *types.NGramStats) error {
+	bigrams, trigrams := m.sessionNGrams()
+	session.SlowNGrams = metrics.SlowNGrams(mergeNGrams(bigrams, trigrams), m.text, slowNGramLimit, minNGramSamples)
+	update := func(ngrams *types.NGramStats) { ngrams.Update(bigrams, trigrams, session.Date) }
+	if m.ngramPath == "" {
+		update(rolling)
+		return nil
+	}
+
+	merged, err := updateNGramStats(m.ngramPath, update)
+	if err != nil {
+		// The dashboard still shows this session's timings
+		update(rolling)
+		return err
+	}
+	*rolling = merged
+	return nil
+}
+
+// formatSlowNGram formats an n-gram with its transition time and sample words
+func formatSlowNGram(ngram string, mean time.Duration, words []string) string {
+	s := fmt.Sprintf("%s %dms", DefaultTheme.Highlight.Render(ngram), mean.Milliseconds())
+	if len(words) > 0 {
+		s += DefaultTheme.Muted.Render(" (" + strings.Join(words, ", ") + ")")
+	}
+	return s
+}
+
+// renderSlowNGrams renders the slowest transitions of the session and across sessions
+func (m dashboardModel) renderSlowNGrams(termWidth int) string {
+	var lines []string
+
+	if len(m.currentSession.SlowNGrams) > 0 {
+		parts := make([]string, len(m.currentSession.SlowNGrams))
+		for i, slow := range m.currentSession.SlowNGrams {
+			parts[i] = formatSlowNGram(slow.NGram, slow.Mean, slow.Words)
+		}
+		lines = append(lines, DefaultTheme.Muted.Render("This session: ")+strings.Join(parts, " • "))
+	}
+
+	rolling := append(types.Slowest(m.ngrams.Trigrams, 3, minRollingSamples), types.Slowest(m.ngrams.Bigrams, 3, minRollingSamples)...)
+	if len(rolling) > 0 {
+		parts := make([]string, len(rolling))
+		for i, ngram := range rolling {
+			mean := m.ngrams.Bigrams[ngram].Mean
+			if len([]rune(ngram)) == 3 {
+				mean = m.ngrams.Trigrams[ngram].Mean
+			}
+			parts[i] = formatSlowNGram(ngram, mean, sources.SampleWords(ngram, 2))
+		}
+		lines = append(lines, DefaultTheme.Muted.Render("All sessions: ")+strings.Join(parts, " • "))
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	center := lipgloss.NewStyle().Align(lipgloss.Center).Width(termWidth)
+	var s strings.Builder
+	s.WriteString(lipgloss.NewStyle().
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderTop(true).
+		BorderForeground(lipgloss.Color("8")).
+		Padding(0, 2).
+		Align(lipgloss.Center).
+		Width(termWidth - 4).
+		Render(DefaultTheme.Info.Render("Slow Transitions")))
+	s.WriteString("\n\n")
+	for i, line := range lines {
+		if i > 0 {
+			s.WriteString("\n")
+		}
+		s.WriteString(center.Render(line))
+	}
+	return s.String()
+}
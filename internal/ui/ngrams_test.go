@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"go-touch/internal/metrics"
+	"go-touch/internal/types"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNGramStats_UpdateAndLoad(t *testing.T) {
+	config := types.Config{Stats: types.StatsConfig{FileDir: filepath.Join(t.TempDir(), "user_stats.json")}}
+
+	stats, err := getNGramStats(config)
+	if err != nil {
+		t.Fatalf("getNGramStats() without a file error: %v", err)
+	}
+	if len(stats.Bigrams) != 0 {
+		t.Errorf("getNGramStats() without a file = %+v, want empty stats", stats)
+	}
+
+	// Two instances finishing sessions side by side each add their own timings
+	var wg sync.WaitGroup
+	for _, ngram := range []string{"th", "he"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := updateNGramStats(ngramStatsPath(config), func(ngrams *types.NGramStats) {
+				ngrams.Update(map[string]types.NGramStat{ngram: {Count: 1, Total: 150 * time.Millisecond}}, nil, time.Now())
+			})
+			if err != nil {
+				t.Errorf("updateNGramStats() error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	loaded, err := getNGramStats(config)
+	if err != nil {
+		t.Fatalf("getNGramStats() error: %v", err)
+	}
+	for _, ngram := range []string{"th", "he"} {
+		if got := loaded.Bigrams[ngram].Mean; got != 150*time.Millisecond {
+			t.Errorf("loaded Bigrams[%s].Mean = %v, want 150ms", ngram, got)
+		}
+	}
+}
+
+func TestUpdateNGramStats_DamagedFileStartsOver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ngram_stats.json")
+	if err := os.WriteFile(path, []byte(`{"bigrams": {"th": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	merged, err := updateNGramStats(path, func(ngrams *types.NGramStats) {
+		ngrams.Update(map[string]types.NGramStat{"th": {Count: 1, Total: 150 * time.Millisecond}}, nil, time.Now())
+	})
+	if err != nil || merged.Bigrams["th"].Samples != 1 {
+		t.Errorf("updateNGramStats() = %+v, %v, want fresh stats with the session's timings", merged, err)
+	}
+}
+
+func TestSessionModel_SlowNGrams(t *testing.T) {
+	model := sessionModel{
+		keystrokes: []metrics.Keystroke{
+			{At: 0, Pos: 0, Expected: 'q', Typed: 'q'},
+			{At: 500 * time.Millisecond, Pos: 1, Expected: 'u', Typed: 'u'},
+			{At: time.Second, Pos: 2, Expected: ' ', Typed: ' '},
+			{At: 1100 * time.Millisecond, Pos: 3, Expected: 'q', Typed: 'q'},
+			{At: 1600 * time.Millisecond, Pos: 4, Expected: 'u', Typed: 'u'},
+		},
+		ngramHistory: types.NGramStats{
+			Bigrams: map[string]types.RollingNGram{
+				"qu": {Mean: 400 * time.Millisecond, Samples: 10},
+				"ck": {Mean: 300 * time.Millisecond, Samples: 10},
+			},
+		},
+	}
+
+	got := model.slowNGrams()
+	if len(got) != 2 || got[0] != "qu" || got[1] != "ck" {
+		t.Errorf("slowNGrams() = %v, want [qu ck] with the session's n-grams first and no duplicates", got)
+	}
+}
+
+func TestSessionModel_SlowNGramsRanksHistoryPerTransition(t *testing.T) {
+	model := sessionModel{
+		ngramHistory: types.NGramStats{
+			Bigrams:  map[string]types.RollingNGram{"ck": {Mean: 300 * time.Millisecond, Samples: 10}},
+			Trigrams: map[string]types.RollingNGram{"str": {Mean: 500 * time.Millisecond, Samples: 10}},
+		},
+	}
+
+	// 500ms over two transitions is quicker than 300ms over one
+	got := model.slowNGrams()
+	if len(got) != 2 || got[0] != "ck" || got[1] != "str" {
+		t.Errorf("slowNGrams() = %v, want [ck str]", got)
+	}
+}
+
+func TestDashboardModel_SlowNGrams(t *testing.T) {
+	session := types.TypingSession{
+		WPM:        50,
+		SlowNGrams: []types.SlowNGram{{NGram: "qu", Mean: 400 * time.Millisecond, Count: 2, Words: []string{"quick"}}},
+	}
+	model := newDashboardModel(types.Config{}, session, types.UserStats{})
+	model.width = 120
+	model.ngrams = types.NGramStats{Trigrams: map[string]types.RollingNGram{"str": {Mean: 350 * time.Millisecond, Samples: 10}}}
+
+	view := model.View()
+	for _, want := range []string{"Slow Transitions", "qu 400ms", "quick", "str 350ms", "street"} {
+		if !contains(view, want) {
+			t.Errorf("Dashboard should show %q", want)
+		}
+	}
+}
+
+func TestSlowNGramWords(t *testing.T) {
+	history := types.NGramStats{
+		Bigrams: map[string]types.RollingNGram{
+			"qu": {Mean: 400 * time.Millisecond, Samples: 10},
+			"th": {Mean: 100 * time.Millisecond, Samples: 2}, // Too few samples to rate
+		},
+	}
+
+	words := slowNGramWords(history, rand.New(rand.NewSource(1)))
+	if len(words) != 1 || !strings.Contains(words[0], "qu") {
+		t.Errorf("slowNGramWords() = %v, want one practice word containing qu", words)
+	}
+}
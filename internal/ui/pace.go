@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"go-touch/internal/types"
+)
+
+// Pace caret targets selectable via ui.pace_caret
+const (
+	paceOff     = "off"     // No pace caret
+	paceFixed   = "fixed"   // ui.pace_caret_wpm
+	paceAverage = "average" // Average WPM of past sessions
+	paceBest    = "best"    // Best WPM of past sessions
+)
+
+// paceWPM returns the pace caret speed for the configured target, 0 when it is off
+// or there is no history to pace against
+func paceWPM(config types.Config, stats types.UserStats) float32 {
+	switch config.Ui.PaceCaret {
+	case "", paceOff:
+		return 0
+	case paceFixed:
+		if config.Ui.PaceCaretWPM < 0 {
+			return 0
+		}
+		return float32(config.Ui.PaceCaretWPM)
+	case paceAverage:
+		avgWPM, _, _ := calculateHistoricalStats(stats)
+		return avgWPM
+	case paceBest:
+		_, bestWPM, _ := calculateHistoricalStats(stats)
+		return bestWPM
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: Unknown pace caret %q, using off\n", config.Ui.PaceCaret)
+		return 0
+	}
+}
+
+// pacePosition returns the text offset the pace caret has reached at now, or -1
+// when it is hidden
+func (m sessionModel) pacePosition(now time.Time) int {
+	if m.paceWPM <= 0 || !m.hasStarted {
+		return -1
+	}
+
+	// A word is five characters
+	chars := int(m.activeElapsed(now).Minutes() * float64(m.paceWPM) * 5)
+	if chars >= len(m.text) {
+		return -1
+	}
+	return chars
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"go-touch/internal/types"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPaceWPM(t *testing.T) {
+	stats := types.UserStats{Sessions: []types.TypingSession{{WPM: 40}, {WPM: 60}}}
+	tests := []struct {
+		name      string
+		paceCaret string
+		wpm       int
+		stats     types.UserStats
+		want      float32
+	}{
+		{"unset", "", 80, stats, 0},
+		{"off", paceOff, 80, stats, 0},
+		{"fixed", paceFixed, 80, stats, 80},
+		{"negative fixed", paceFixed, -5, stats, 0},
+		{"average", paceAverage, 0, stats, 50},
+		{"best", paceBest, 0, stats, 60},
+		{"best without history", paceBest, 0, types.UserStats{}, 0},
+		{"unknown", "ghost", 80, stats, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := types.Config{}
+			config.Ui.PaceCaret = tt.paceCaret
+			config.Ui.PaceCaretWPM = tt.wpm
+			if got := paceWPM(config, tt.stats); got != tt.want {
+				t.Errorf("paceWPM() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPacePosition(t *testing.T) {
+	now := time.Now()
+	text := strings.Repeat("a", 100)
+	tests := []struct {
+		name    string
+		paceWPM float32
+		started bool
+		elapsed time.Duration
+		want    int
+	}{
+		{"off", 0, true, 10 * time.Second, -1},
+		{"not started", 60, false, 0, -1},
+		// 60 WPM is 5 characters a second
+		{"running", 60, true, 10 * time.Second, 50},
+		{"past the end", 60, true, time.Minute, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := sessionModel{text: text, paceWPM: tt.paceWPM, hasStarted: tt.started}
+			if tt.started {
+				m.startTime = now.Add(-tt.elapsed)
+			}
+			if got := m.pacePosition(now); got != tt.want {
+				t.Errorf("pacePosition() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPacePosition_ExcludesPauses(t *testing.T) {
+	now := time.Now()
+	m := sessionModel{
+		text:       strings.Repeat("a", 100),
+		paceWPM:    60,
+		hasStarted: true,
+		startTime:  now.Add(-20 * time.Second),
+		pauses:     []types.Pause{{Start: now.Add(-15 * time.Second), Duration: 10 * time.Second}},
+	}
+	if got := m.pacePosition(now); got != 50 {
+		t.Errorf("pacePosition() = %d, want 50 with 10s paused", got)
+	}
+}
+
+func TestRenderChar_PaceCaret(t *testing.T) {
+	m := sessionModel{text: "hello world", typedText: "he"}
+
+	if got, want := m.renderChar(6, false, 6, DefaultTheme.Normal), DefaultTheme.PaceCaret.Render("w"); got != want {
+		t.Errorf("renderChar() at the pace caret = %q, want %q", got, want)
+	}
+	// The cursor wins when both carets are on the same character
+	if got, want := m.renderChar(2, false, 2, DefaultTheme.Normal), DefaultTheme.Current.Render("l"); got != want {
+		t.Errorf("renderChar() at the cursor = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"fmt"
+	"go-touch/internal/types"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultIdleThreshold is used when the config does not set idle_threshold_seconds
+const defaultIdleThreshold = 10 * time.Second
+
+// idleThreshold returns how long the user may be inactive before the session auto-pauses.
+// A negative config value disables idle detection.
+func idleThreshold(config types.Config) time.Duration {
+	seconds := config.Session.IdleThresholdSeconds
+	if seconds < 0 {
+		return 0
+	}
+	if seconds == 0 {
+		return defaultIdleThreshold
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// activeElapsed returns the time since the session started at now, excluding time spent paused
+func (m sessionModel) activeElapsed(now time.Time) time.Duration {
+	if m.startTime.IsZero() {
+		return 0
+	}
+	elapsed := now.Sub(m.startTime)
+	for _, pause := range m.pauses {
+		if !pause.Start.Before(now) {
+			continue
+		}
+		end := pause.Start.Add(pause.Duration)
+		if end.After(now) {
+			end = now
+		}
+		elapsed -= end.Sub(pause.Start)
+	}
+	if m.paused && m.pausedAt.Before(now) {
+		elapsed -= now.Sub(m.pausedAt)
+	}
+	if elapsed < 0 {
+		return 0
+	}
+	return elapsed
+}
+
+// pause stops the session clock from the given time on
+func (m *sessionModel) pause(at time.Time, auto bool) {
+	if m.paused {
+		return
+	}
+	m.paused = true
+	m.pausedAt = at
+	m.pauseIsAuto = auto
+}
+
+// resume restarts the session clock and records the finished pause
+func (m *sessionModel) resume(now time.Time) {
+	if !m.paused {
+		return
+	}
+	m.pauses = append(m.pauses, types.Pause{
+		Start:    m.pausedAt,
+		Duration: now.Sub(m.pausedAt),
+		Auto:     m.pauseIsAuto,
+	})
+	m.paused = false
+	m.pausedAt = time.Time{}
+	m.pauseIsAuto = false
+	// Keystroke timing restarts after the pause so the gap does not count as latency
+	m.lastKeyTime = now
+}
+
+// pausedTime sums the duration of all recorded pauses
+func pausedTime(pauses []types.Pause) time.Duration {
+	var total time.Duration
+	for _, pause := range pauses {
+		total += pause.Duration
+	}
+	return total
+}
+
+// pauseOverlay renders the box shown over the session while it is paused
+func (m sessionModel) pauseOverlay() string {
+	reason := "Paused"
+	if m.pauseIsAuto {
+		reason = "Paused after inactivity"
+	}
+
+	content := fmt.Sprintf("%s\n\n%s\n\n%s",
+		DefaultTheme.Title.Render(reason),
+		fmt.Sprintf("WPM: %.0f | Accuracy: %.1f%% | Errors: %d", m.getCurrentWPM(), m.getCurrentAccuracy(), m.errors),
+		DefaultTheme.Muted.Render("Press any key to resume • ESC or CTRL-C to exit"))
+
+	box := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("6")).
+		Padding(1, 3).
+		Align(lipgloss.Center).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
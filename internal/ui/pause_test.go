@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"go-touch/internal/types"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestIdleThreshold(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    time.Duration
+	}{
+		{0, defaultIdleThreshold},
+		{30, 30 * time.Second},
+		{-1, 0},
+	}
+
+	for _, tt := range tests {
+		config := types.Config{Session: types.SessionConfig{IdleThresholdSeconds: tt.seconds}}
+		if got := idleThreshold(config); got != tt.want {
+			t.Errorf("idleThreshold(%d) = %v, want %v", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestActiveElapsed_ExcludesPauses(t *testing.T) {
+	start := time.Now().Add(-time.Minute)
+	model := sessionModel{
+		startTime: start,
+		pauses: []types.Pause{
+			{Start: start.Add(10 * time.Second), Duration: 5 * time.Second},
+		},
+		paused:   true,
+		pausedAt: start.Add(40 * time.Second),
+	}
+
+	// 60s since start, minus the finished 5s pause and the 20s ongoing pause
+	now := start.Add(time.Minute)
+	if got := model.activeElapsed(now); got != 35*time.Second {
+		t.Errorf("activeElapsed() = %v, want 35s", got)
+	}
+
+	// Before the first pause nothing is excluded
+	if got := model.activeElapsed(start.Add(8 * time.Second)); got != 8*time.Second {
+		t.Errorf("activeElapsed() before pauses = %v, want 8s", got)
+	}
+
+	// During the first pause only its elapsed part is excluded
+	if got := model.activeElapsed(start.Add(12 * time.Second)); got != 10*time.Second {
+		t.Errorf("activeElapsed() during pause = %v, want 10s", got)
+	}
+}
+
+func TestSessionModel_IdleAutoPause(t *testing.T) {
+	lastKey := time.Now().Add(-15 * time.Second)
+	model := sessionModel{
+		text:            "test text",
+		hasStarted:      true,
+		startTime:       time.Now().Add(-20 * time.Second),
+		lastKeyTime:     lastKey,
+		sessionDuration: time.Minute,
+		idleLimit:       10 * time.Second,
+	}
+
+	updatedModel, cmd := model.Update(tickMsg(time.Now()))
+	m := updatedModel.(sessionModel)
+
+	if !m.paused || !m.pauseIsAuto {
+		t.Fatalf("Session should auto-pause after idling, paused=%v auto=%v", m.paused, m.pauseIsAuto)
+	}
+	if !m.pausedAt.Equal(lastKey) {
+		t.Errorf("Auto-pause should start at the last keystroke, got %v want %v", m.pausedAt, lastKey)
+	}
+	if cmd == nil {
+		t.Error("Ticking should continue while paused")
+	}
+}
+
+func TestSessionModel_PausedSessionDoesNotExpire(t *testing.T) {
+	model := sessionModel{
+		text:            "test text",
+		hasStarted:      true,
+		startTime:       time.Now().Add(-2 * time.Minute),
+		lastKeyTime:     time.Now().Add(-2 * time.Minute),
+		sessionDuration: time.Minute,
+		paused:          true,
+		pausedAt:        time.Now().Add(-110 * time.Second),
+	}
+
+	updatedModel, _ := model.Update(tickMsg(time.Now()))
+	if updatedModel.(sessionModel).completed {
+		t.Error("Paused time should not count towards the session duration")
+	}
+}
+
+func TestSessionModel_PauseKeyAndResume(t *testing.T) {
+	model := sessionModel{
+		text:                "test",
+		hasStarted:          true,
+		startTime:           time.Now(),
+		lastKeyTime:         time.Now(),
+		wordsWithErrors:     map[int]bool{},
+		currentProblemWords: []string{},
+		width:               80,
+		height:              24,
+	}
+
+	updatedModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	m := updatedModel.(sessionModel)
+	if !m.paused || m.pauseIsAuto {
+		t.Fatalf("CTRL-P should pause manually, paused=%v auto=%v", m.paused, m.pauseIsAuto)
+	}
+	if !contains(m.View(), "Paused") {
+		t.Error("View should show the pause overlay")
+	}
+
+	// The key that resumes is not typed
+	updatedModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m = updatedModel.(sessionModel)
+	if m.paused {
+		t.Error("Any key should resume the session")
+	}
+	if m.typedText != "" {
+		t.Errorf("Resuming key should not be typed, got %q", m.typedText)
+	}
+	if len(m.pauses) != 1 || m.pauses[0].Auto {
+		t.Errorf("Expected one manual pause to be recorded, got %+v", m.pauses)
+	}
+}
+
+func TestSessionModel_BackspaceResumesPause(t *testing.T) {
+	m := newModeTestModel(types.ModeText, "the cat", "the")
+	m.pause(time.Now(), true)
+
+	updatedModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = updatedModel.(sessionModel)
+	if m.paused {
+		t.Error("Backspace should resume the session")
+	}
+	if m.typedText != "the" || len(m.keystrokes) != 0 {
+		t.Errorf("Resuming backspace should not delete, got %q and %d keystrokes", m.typedText, len(m.keystrokes))
+	}
+}
+
+func TestTypingSession_WasIdle(t *testing.T) {
+	manual := types.TypingSession{Pauses: []types.Pause{{Duration: time.Second}}}
+	if manual.WasIdle() {
+		t.Error("Session with only manual pauses should not be idle")
+	}
+
+	idle := types.TypingSession{Pauses: []types.Pause{{Duration: time.Second}, {Duration: time.Minute, Auto: true}}}
+	if !idle.WasIdle() {
+		t.Error("Session with an auto-pause should be idle")
+	}
+}
+
+func TestPausedTime(t *testing.T) {
+	pauses := []types.Pause{{Duration: 3 * time.Second}, {Duration: 7 * time.Second}}
+	if got := pausedTime(pauses); got != 10*time.Second {
+		t.Errorf("pausedTime() = %v, want 10s", got)
+	}
+}
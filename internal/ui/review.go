@@ -0,0 +1,196 @@
+package ui
+
+import (
+	"fmt"
+	"go-touch/internal/metrics"
+	"go-touch/internal/types"
+	"math/rand"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dashboardTab selects the dashboard page
+type dashboardTab int
+
+const (
+	dashboardSummary dashboardTab = iota
+	dashboardReview
+)
+
+const (
+	// drillRepetitions is how often each word appears in a drill
+	drillRepetitions = 3
+	// maxDrillWords caps the distinct words in a drill to keep it short
+	maxDrillWords = 10
+)
+
+// mistypedWords lists the words of text whose first attempt contained a
+// mistake, in order of appearance, with corrected mistakes included
+func mistypedWords(text string, keystrokes []metrics.Keystroke) []types.MistypedWord {
+	// First character typed at each position, before any correction
+	firstAttempt := make(map[int]byte)
+	for _, k := range keystrokes {
+		if k.Backspace || k.Pos >= len(text) {
+			continue
+		}
+		if _, ok := firstAttempt[k.Pos]; !ok && k.Typed < 0x80 {
+			firstAttempt[k.Pos] = byte(k.Typed)
+		}
+	}
+
+	var words []types.MistypedWord
+	index := make(map[string]int)
+	start := -1
+	for i := 0; i <= len(text); i++ {
+		if i < len(text) && text[i] != ' ' {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start < 0 {
+			continue
+		}
+
+		target := text[start:i]
+		var typed strings.Builder
+		wrong := false
+		for pos := start; pos < i; pos++ {
+			c, ok := firstAttempt[pos]
+			if !ok {
+				break
+			}
+			typed.WriteByte(c)
+			wrong = wrong || c != text[pos]
+		}
+		start = -1
+
+		if !wrong {
+			continue
+		}
+		if n, ok := index[target]; ok {
+			words[n].Count++
+			continue
+		}
+		index[target] = len(words)
+		words = append(words, types.MistypedWord{Target: target, Typed: typed.String(), Count: 1})
+	}
+	return words
+}
+
+// drillText repeats each word drillRepetitions times in varied order,
+// avoiding the same word twice in a row where possible
+func drillText(words []types.MistypedWord, rng *rand.Rand) string {
+	if len(words) > maxDrillWords {
+		words = words[:maxDrillWords]
+	}
+
+	remaining := make([]int, len(words))
+	for i := range remaining {
+		remaining[i] = drillRepetitions
+	}
+
+	// Always pick among the words with the most repetitions left, so the
+	// last ones are never forced back to back
+	var drill []string
+	prev := -1
+	for len(drill) < len(words)*drillRepetitions {
+		var candidates []int
+		most := 0
+		for i, left := range remaining {
+			if left == 0 || (i == prev && len(words) > 1) {
+				continue
+			}
+			if left > most {
+				most, candidates = left, candidates[:0]
+			}
+			if left == most {
+				candidates = append(candidates, i)
+			}
+		}
+		if len(candidates) == 0 {
+			// Only the previous word is left
+			candidates = []int{prev}
+		}
+
+		pick := candidates[rng.Intn(len(candidates))]
+		drill = append(drill, words[pick].Target)
+		remaining[pick]--
+		prev = pick
+	}
+
+	return strings.Join(drill, " ")
+}
+
+// renderTabs renders the dashboard tab bar with the active tab highlighted
+func (m dashboardModel) renderTabs() string {
+	review := "Review"
+	if n := len(m.currentSession.MistypedWords); n > 0 {
+		review = fmt.Sprintf("Review (%d)", n)
+	}
+
+	tabs := []string{"Summary", review}
+	for i, tab := range tabs {
+		if dashboardTab(i) == m.tab {
+			tabs[i] = DefaultTheme.Highlight.Render("[ " + tab + " ]")
+		} else {
+			tabs[i] = DefaultTheme.Muted.Render("  " + tab + "  ")
+		}
+	}
+	return strings.Join(tabs, "  ") + DefaultTheme.Muted.Render("   ←/→ to switch")
+}
+
+// renderTypedWord styles the typed attempt against the target, marking
+// wrong characters in red and characters never typed as underscores
+func renderTypedWord(target, typed string) string {
+	var s strings.Builder
+	for i := 0; i < len(target); i++ {
+		switch {
+		case i >= len(typed):
+			s.WriteString(DefaultTheme.Muted.Render("_"))
+		case typed[i] == target[i]:
+			s.WriteString(DefaultTheme.Correct.Render(string(typed[i])))
+		default:
+			s.WriteString(DefaultTheme.Incorrect.Render(string(typed[i])))
+		}
+	}
+	return s.String()
+}
+
+// renderReview renders the mistyped words of the session with the drill action
+func (m dashboardModel) renderReview(termWidth int) string {
+	center := lipgloss.NewStyle().Align(lipgloss.Center).Width(termWidth)
+	words := m.currentSession.MistypedWords
+
+	var s strings.Builder
+	if len(words) == 0 {
+		s.WriteString(center.Render(DefaultTheme.Muted.Render("No mistyped words this session")))
+		s.WriteString("\n\n")
+		s.WriteString(center.Render(DefaultTheme.Muted.Render("Press Enter to exit...")))
+		return s.String()
+	}
+
+	width := 0
+	for _, word := range words {
+		if len(word.Target) > width {
+			width = len(word.Target)
+		}
+	}
+
+	var rows []string
+	rows = append(rows, DefaultTheme.Muted.Render(fmt.Sprintf("%-*s   %s", width, "Target", "Typed")))
+	for _, word := range words {
+		row := fmt.Sprintf("%-*s   %s", width, word.Target, renderTypedWord(word.Target, word.Typed))
+		if word.Count > 1 {
+			row += DefaultTheme.Muted.Render(fmt.Sprintf("  ×%d", word.Count))
+		}
+		rows = append(rows, row)
+	}
+
+	// Center the list as a block so the columns stay aligned
+	s.WriteString(center.Render(lipgloss.NewStyle().Align(lipgloss.Left).Render(strings.Join(rows, "\n"))))
+	s.WriteString("\n\n")
+	s.WriteString(center.Render(DefaultTheme.Muted.Render("Press D to drill these words • Enter to exit")))
+	return s.String()
+}
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"go-touch/internal/metrics"
+	"go-touch/internal/types"
+	"math/rand"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// typeKeys builds a keystroke log from typed characters, '<' meaning backspace
+func typeKeys(text, keys string) []metrics.Keystroke {
+	var keystrokes []metrics.Keystroke
+	pos := 0
+	for _, key := range keys {
+		if key == '<' {
+			pos--
+			keystrokes = append(keystrokes, metrics.Keystroke{Pos: pos, Backspace: true})
+			continue
+		}
+		k := metrics.Keystroke{Pos: pos, Typed: key}
+		if pos < len(text) {
+			k.Expected = rune(text[pos])
+		}
+		keystrokes = append(keystrokes, k)
+		pos++
+	}
+	return keystrokes
+}
+
+func TestMistypedWords(t *testing.T) {
+	text := "the cat the dog sat"
+	// "cat" typed "cst" and corrected, both "the"s as "teh", "sat" abandoned after "x"
+	keystrokes := typeKeys(text, "teh cs<at teh dog x")
+
+	got := mistypedWords(text, keystrokes)
+	want := []types.MistypedWord{
+		{Target: "the", Typed: "teh", Count: 2},
+		{Target: "cat", Typed: "cst", Count: 1},
+		{Target: "sat", Typed: "x", Count: 1},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("mistypedWords() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("mistypedWords()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDrillText(t *testing.T) {
+	words := []types.MistypedWord{{Target: "alpha"}, {Target: "beta"}, {Target: "gamma"}}
+
+	for seed := int64(0); seed < 20; seed++ {
+		drill := strings.Fields(drillText(words, rand.New(rand.NewSource(seed))))
+
+		if len(drill) != len(words)*drillRepetitions {
+			t.Fatalf("drillText() has %d words, want %d", len(drill), len(words)*drillRepetitions)
+		}
+		counts := make(map[string]int)
+		for i, word := range drill {
+			counts[word]++
+			if i > 0 && drill[i-1] == word {
+				t.Errorf("drillText() with seed %d repeats %q back to back: %v", seed, word, drill)
+			}
+		}
+		for _, word := range words {
+			if counts[word.Target] != drillRepetitions {
+				t.Errorf("drillText() has %q %d times, want %d", word.Target, counts[word.Target], drillRepetitions)
+			}
+		}
+	}
+}
+
+func TestDashboardModel_ReviewTab(t *testing.T) {
+	session := types.TypingSession{
+		WPM:           50,
+		MistypedWords: []types.MistypedWord{{Target: "world", Typed: "wrold", Count: 2}},
+	}
+	model := newDashboardModel(types.Config{}, session, types.UserStats{})
+
+	// D does nothing on the summary tab
+	updatedModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if updatedModel.(dashboardModel).drill {
+		t.Error("D should only drill from the review tab")
+	}
+
+	updatedModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m := updatedModel.(dashboardModel)
+	view := m.View()
+	for _, want := range []string{"Review (1)", "world", "×2", "drill these words"} {
+		if !contains(view, want) {
+			t.Errorf("Review tab should show %q", want)
+		}
+	}
+
+	updatedModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if !updatedModel.(dashboardModel).drill || cmd == nil {
+		t.Error("D on the review tab should request a drill and quit the dashboard")
+	}
+}
+
+func TestSessionModel_DrillStartsOnFirstKey(t *testing.T) {
+	model := newSessionModel(types.Config{}, "word word", nil, nil, types.NGramStats{})
+	model.mode = types.ModeText
+	model.drill = true
+
+	if contains(model.View(), "Session Configuration") {
+		t.Error("Drills should skip the configuration screen")
+	}
+
+	updatedModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if updatedModel.(sessionModel).activeMode() != types.ModeText {
+		t.Error("Drills should stay in text mode")
+	}
+
+	updatedModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	m := updatedModel.(sessionModel)
+	if !m.hasStarted || m.typedText != "w" {
+		t.Errorf("First key should start the drill and be typed, got hasStarted=%v typedText=%q", m.hasStarted, m.typedText)
+	}
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"go-touch/internal/sources"
+	"go-touch/internal/srs"
+	"go-touch/internal/types"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// maxDueWords caps the review words woven into one session
+	maxDueWords = 8
+	// maxDueChars caps the review characters practised in one session
+	maxDueChars = 3
+	// minCharPresses is how often a character must be typed before it can count as clean
+	minCharPresses = 5
+	// missCharRate is the error rate at which a character counts as missed
+	missCharRate = 0.05
+)
+
+// deckPath returns the spaced repetition deck file, stored next to the session stats
+func deckPath(config types.Config) string {
+	return filepath.Join(filepath.Dir(config.Stats.FileDir), "review_deck.json")
+}
+
+// reviewWord strips surrounding punctuation so "world," and "World" review as "world"
+func reviewWord(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }))
+}
+
+// dueReviews returns the words to weave into the next session and the characters to practise
+func dueReviews(deck *srs.Deck, now time.Time, rng *rand.Rand) (words []string, chars []rune) {
+	for _, card := range deck.Due(srs.KindWord, now, maxDueWords) {
+		words = append(words, card.Value)
+	}
+	for _, card := range deck.Due(srs.KindChar, now, maxDueChars) {
+		char := []rune(card.Value)[0]
+		chars = append(chars, char)
+		// Characters are practised through a word containing them
+		if samples := sources.SampleWords(card.Value, 5); len(samples) > 0 {
+			words = append(words, samples[rng.Intn(len(samples))])
+		}
+	}
+	return words, chars
+}
+
+// weaveWords inserts each word at a random word boundary of text, never before the first word
+func weaveWords(text string, words []string, rng *rand.Rand) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return strings.Join(words, " ")
+	}
+	for _, word := range words {
+		at := 1 + rng.Intn(len(fields))
+		fields = append(fields[:at], append([]string{word}, fields[at:]...)...)
+	}
+	return strings.Join(fields, " ")
+}
+
+// seedReviews makes the adaptive source target the due words and characters from the first sentence on
+func (m *sessionModel) seedReviews(words []string, chars []rune) {
+	m.problemWords = append(m.problemWords, words...)
+	for _, char := range chars {
+		m.errorPatterns[char]++
+	}
+}
+
+// updateDeck schedules the words and characters of a finished session: every
+// mistyped word and error-prone character is missed, and cards typed cleanly move on
+func (m sessionModel) updateDeck(deck *srs.Deck, session types.TypingSession, now time.Time) {
+	missed := make(map[string]bool)
+	for _, word := range session.MistypedWords {
+		if key := reviewWord(word.Target); key != "" {
+			missed[key] = true
+			deck.Miss(srs.KindWord, key, now)
+		}
+	}
+
+	// Words typed in full without mistakes
+	typed := len(m.typedText)
+	if typed > len(m.text) {
+		typed = len(m.text)
+	}
+	fields := strings.Fields(m.text[:typed])
+	if typed < len(m.text) && m.text[typed] != ' ' && len(fields) > 0 {
+		// The last word was cut off
+		fields = fields[:len(fields)-1]
+	}
+	reviewed := make(map[string]bool)
+	for _, field := range fields {
+		key := reviewWord(field)
+		if key == "" || missed[key] || reviewed[key] {
+			continue
+		}
+		reviewed[key] = true
+		deck.Clean(srs.KindWord, key, now)
+	}
+
+	// Upper and lower case count as one character
+	chars := make(map[string]types.KeyStat)
+	for char, stat := range session.KeyStats {
+		runes := []rune(char)
+		if len(runes) == 1 && unicode.IsLetter(runes[0]) {
+			key := strings.ToLower(char)
+			chars[key] = chars[key].Add(stat)
+		}
+	}
+	for key, stat := range chars {
+		switch {
+		case stat.Errors >= 2 && stat.ErrorRate() >= missCharRate:
+			deck.Miss(srs.KindChar, key, now)
+		case stat.Errors == 0 && stat.Presses >= minCharPresses:
+			deck.Clean(srs.KindChar, key, now)
+		}
+	}
+}
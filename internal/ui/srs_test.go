@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"go-touch/internal/srs"
+	"go-touch/internal/types"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWeaveWords(t *testing.T) {
+	text := "The quick brown fox"
+	woven := weaveWords(text, []string{"alpha", "beta"}, rand.New(rand.NewSource(1)))
+
+	fields := strings.Fields(woven)
+	if len(fields) != 6 {
+		t.Fatalf("weaveWords() = %q, want 6 words", woven)
+	}
+	if fields[0] != "The" {
+		t.Errorf("weaveWords() = %q, want the text to keep its first word", woven)
+	}
+	for _, want := range []string{"alpha", "beta"} {
+		if !strings.Contains(woven, want) {
+			t.Errorf("weaveWords() = %q, want it to contain %q", woven, want)
+		}
+	}
+}
+
+func TestDueReviews(t *testing.T) {
+	now := time.Now()
+	deck := srs.Deck{Cards: []srs.Card{
+		{Kind: srs.KindWord, Value: "world", Due: now},
+		{Kind: srs.KindWord, Value: "later", Due: now.Add(time.Hour)},
+		{Kind: srs.KindChar, Value: "q", Due: now},
+	}}
+
+	words, chars := dueReviews(&deck, now, rand.New(rand.NewSource(1)))
+
+	if len(chars) != 1 || chars[0] != 'q' {
+		t.Errorf("dueReviews() chars = %q, want [q]", chars)
+	}
+	if len(words) != 2 || words[0] != "world" || !strings.Contains(words[1], "q") {
+		t.Errorf("dueReviews() words = %v, want world and a word containing q", words)
+	}
+}
+
+func TestSessionModel_UpdateDeck(t *testing.T) {
+	now := time.Now()
+	deck := srs.Deck{Cards: []srs.Card{
+		{Kind: srs.KindWord, Value: "hello", Due: now},
+		{Kind: srs.KindWord, Value: "there", Due: now},
+		{Kind: srs.KindChar, Value: "e", Due: now},
+	}}
+	model := sessionModel{
+		text:      "Hello, world there",
+		typedText: "Hello, wrold th",
+	}
+	session := types.TypingSession{
+		MistypedWords: []types.MistypedWord{{Target: "world", Typed: "wrold", Count: 1}},
+		KeyStats: map[string]types.KeyStat{
+			"e": {Presses: 4},
+			"E": {Presses: 1},
+			"o": {Presses: 10, Errors: 2},
+		},
+	}
+
+	model.updateDeck(&deck, session, now)
+
+	if card := deck.Cards[0]; card.Box != 1 {
+		t.Errorf("hello = %+v, want moved to box 1 after a clean review", card)
+	}
+	if card := deck.Cards[1]; card.Box != 0 {
+		t.Errorf("there = %+v, want unchanged as it was not typed in full", card)
+	}
+	if card := deck.Cards[2]; card.Box != 1 {
+		t.Errorf("e = %+v, want moved to box 1 counting both cases", card)
+	}
+	if !deck.Has(srs.KindWord, "world") {
+		t.Error("Mistyped word should be added to the deck")
+	}
+	if !deck.Has(srs.KindChar, "o") {
+		t.Error("Error-prone character should be added to the deck")
+	}
+}
@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"go-touch/internal/metrics"
+)
+
+// Backspace policies selectable via ui.backspace
+const (
+	backspaceUnlimited = "unlimited" // Delete anything typed so far
+	backspaceWord      = "word"      // Delete within the current word only
+	backspaceDisabled  = "disabled"  // No corrections at all
+)
+
+// Error handling selectable via ui.error_mode
+const (
+	errorModeNormal  = "normal"   // Errors stay in place and can be corrected
+	errorModeFixWord = "fix_word" // A word cannot be left until it is typed correctly
+	errorModeSkip    = "skip"     // Errors are marked and passed over, keeping typing aligned with the words
+)
+
+// skippedChar fills typedText for letters passed over in skip mode; it never
+// matches the text and does not count towards speed or accuracy
+const skippedChar = metrics.SkippedChar
+
+// backspaceMode returns the configured backspace policy. Fixing words needs
+// corrections, so disabled backspace is relaxed to the current word there.
+func (m sessionModel) backspaceMode() string {
+	switch m.config.Ui.Backspace {
+	case backspaceWord:
+		return backspaceWord
+	case backspaceDisabled:
+		if m.errorMode() == errorModeFixWord {
+			return backspaceWord
+		}
+		return backspaceDisabled
+	default:
+		return backspaceUnlimited
+	}
+}
+
+// errorMode returns the configured error handling, normal when unset or unknown
+func (m sessionModel) errorMode() string {
+	switch m.config.Ui.ErrorMode {
+	case errorModeFixWord, errorModeSkip:
+		return m.config.Ui.ErrorMode
+	default:
+		return errorModeNormal
+	}
+}
+
+// canBackspace reports whether the backspace policy allows deleting the last typed character
+func (m sessionModel) canBackspace() bool {
+	switch m.backspaceMode() {
+	case backspaceDisabled:
+		return false
+	case backspaceWord:
+		// A typed space closes the word before it
+		return !strings.HasSuffix(m.typedText, " ")
+	default:
+		return true
+	}
+}
+
+// wordStart returns the offset of the word containing pos
+func wordStart(text string, pos int) int {
+	return strings.LastIndexByte(text[:pos], ' ') + 1
+}
+
+// typedCorrectly reports whether the typed text matches the target over [start, end)
+func (m sessionModel) typedCorrectly(start, end int) bool {
+	return end <= len(m.typedText) && end <= len(m.text) && m.typedText[start:end] == m.text[start:end]
+}
+
+// leavesWrongWord reports whether key would move past the end of a word that
+// still has errors, which fix_word refuses
+func (m sessionModel) leavesWrongWord(key string) bool {
+	pos := len(m.typedText)
+	if pos >= len(m.text) {
+		return false
+	}
+	// The space after a word belongs to it, so a wrong key typed there has to
+	// be fixed before the next word
+	if pos > 0 && m.text[pos-1] == ' ' && !m.typedCorrectly(wordStart(m.text, pos-1), pos) {
+		return true
+	}
+	start := wordStart(m.text, pos)
+	switch {
+	case m.text[pos] == ' ':
+		// The space after the word
+		return !m.typedCorrectly(start, pos)
+	case pos == len(m.text)-1:
+		// The last character of the text ends the session
+		return !m.typedCorrectly(start, pos) || key != m.text[pos:]
+	}
+	return false
+}
+
+// isExtraLetter reports whether key is a letter typed where the word has already
+// ended, which skip mode swallows
+func (m sessionModel) isExtraLetter(key string) bool {
+	pos := len(m.typedText)
+	return pos < len(m.text) && m.text[pos] == ' ' && key != " "
+}
+
+// skipPadding returns the filler for the rest of the current word when space is
+// typed early in skip mode, or "" when there is nothing to pass over
+func (m sessionModel) skipPadding(key string) string {
+	pos := len(m.typedText)
+	if key != " " || pos >= len(m.text) || m.text[pos] == ' ' {
+		return ""
+	}
+	next := strings.IndexByte(m.text[pos:], ' ')
+	if next < 0 {
+		// The last word has no space to skip to
+		return ""
+	}
+	return strings.Repeat(skippedChar, next)
+}
+
+// recordExtraLetter logs a swallowed extra letter as an error against the word it followed
+func (m *sessionModel) recordExtraLetter(key string, at time.Time) {
+	pos := len(m.typedText)
+	m.keystrokes = append(m.keystrokes, metrics.Keystroke{
+		At:       m.activeElapsed(at),
+		Pos:      pos,
+		Expected: ' ',
+		Typed:    rune(key[0]),
+	})
+	m.errors++
+	if pos > 0 {
+		m.wordsWithErrors[pos-1] = true
+	}
+	m.wrongKey = rune(key[0])
+	m.wrongKeyTime = at
+	m.lastKeyTime = at
+}
+
+// strictnessLabel summarises non-default strictness options for the configuration screen
+func (m sessionModel) strictnessLabel() string {
+	var parts []string
+	switch m.backspaceMode() {
+	case backspaceWord:
+		parts = append(parts, "backspace within word")
+	case backspaceDisabled:
+		parts = append(parts, "no backspace")
+	}
+	switch m.errorMode() {
+	case errorModeFixWord:
+		parts = append(parts, "fix every word")
+	case errorModeSkip:
+		parts = append(parts, "errors skipped")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "Strict: " + strings.Join(parts, " • ")
+}
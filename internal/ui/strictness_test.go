@@ -0,0 +1,211 @@
+package ui
+
+import (
+	"go-touch/internal/metrics"
+	"go-touch/internal/types"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newStrictTestModel returns a running session with the given backspace policy and error mode
+func newStrictTestModel(text, typed, backspace, errorMode string) sessionModel {
+	m := newModeTestModel(types.ModeText, text, typed)
+	m.config.Ui.Backspace = backspace
+	m.config.Ui.ErrorMode = errorMode
+	return m
+}
+
+// pressKeys sends each character of keys to the model, '<' as backspace
+func pressKeys(m sessionModel, keys string) sessionModel {
+	for _, key := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}}
+		switch key {
+		case '<':
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		case ' ':
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+		}
+		updated, _ := m.Update(msg)
+		m = updated.(sessionModel)
+	}
+	return m
+}
+
+func TestBackspaceMode(t *testing.T) {
+	tests := []struct {
+		backspace string
+		errorMode string
+		want      string
+	}{
+		{"", "", backspaceUnlimited},
+		{"bogus", "", backspaceUnlimited},
+		{backspaceWord, "", backspaceWord},
+		{backspaceDisabled, "", backspaceDisabled},
+		{backspaceDisabled, errorModeFixWord, backspaceWord},
+	}
+	for _, tt := range tests {
+		m := newStrictTestModel("text", "", tt.backspace, tt.errorMode)
+		if got := m.backspaceMode(); got != tt.want {
+			t.Errorf("backspaceMode() with %q/%q = %q, want %q", tt.backspace, tt.errorMode, got, tt.want)
+		}
+	}
+}
+
+func TestSessionModel_Backspace(t *testing.T) {
+	tests := []struct {
+		name      string
+		backspace string
+		typed     string
+		want      string
+	}{
+		{"unlimited within word", backspaceUnlimited, "the ca", "the c"},
+		{"unlimited across words", backspaceUnlimited, "the ", "the"},
+		{"word within word", backspaceWord, "the ca", "the c"},
+		{"word across words", backspaceWord, "the ", "the "},
+		{"disabled", backspaceDisabled, "the ca", "the ca"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := pressKeys(newStrictTestModel("the cat", tt.typed, tt.backspace, ""), "<")
+			if m.typedText != tt.want {
+				t.Errorf("typedText after backspace = %q, want %q", m.typedText, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionModel_BackspaceDisabledOverridesBlockOnTypo(t *testing.T) {
+	m := newStrictTestModel("the cat", "", backspaceDisabled, "")
+	m.config.Ui.BlockOnTypo = true
+
+	m = pressKeys(m, "tx")
+	if !m.hasTypo {
+		t.Fatal("hasTypo = false after a wrong key")
+	}
+	m = pressKeys(m, "e")
+	if m.typedText != "txe" {
+		t.Errorf("typedText = %q, want %q: blocking on typos needs backspace", m.typedText, "txe")
+	}
+}
+
+func TestSessionModel_FixWord(t *testing.T) {
+	m := pressKeys(newStrictTestModel("the cat", "", "", errorModeFixWord), "tha ")
+	if m.typedText != "tha" {
+		t.Fatalf("typedText = %q, want %q: a wrong word cannot be left", m.typedText, "tha")
+	}
+
+	m = pressKeys(m, "<e ca")
+	if m.typedText != "the ca" {
+		t.Fatalf("typedText = %q, want %q once the word is fixed", m.typedText, "the ca")
+	}
+
+	// The last character cannot finish the text with an error either
+	m = pressKeys(m, "x")
+	if m.completed || m.typedText != "the ca" {
+		t.Errorf("typedText = %q, completed = %v, want the wrong last key refused", m.typedText, m.completed)
+	}
+	m = pressKeys(m, "t")
+	if !m.completed {
+		t.Error("completed = false after typing the text correctly")
+	}
+
+	// Each wrong keystroke still counts against accuracy
+	if m.errors != 1 {
+		t.Errorf("errors = %d, want 1", m.errors)
+	}
+}
+
+func TestSessionModel_FixWordSeparator(t *testing.T) {
+	// A wrong key where the space belongs is typed, but the next word cannot be started
+	m := pressKeys(newStrictTestModel("the cat", "", "", errorModeFixWord), "thexc")
+	if m.typedText != "thex" {
+		t.Fatalf("typedText = %q, want %q: the wrong separator has to be fixed", m.typedText, "thex")
+	}
+	if m.errors != 1 {
+		t.Errorf("errors = %d, want 1 for the wrong separator", m.errors)
+	}
+
+	m = pressKeys(m, "< cat")
+	if m.typedText != "the cat" || !m.completed {
+		t.Errorf("typedText = %q, completed = %v, want the text finished once the space is fixed", m.typedText, m.completed)
+	}
+}
+
+func TestSessionModel_SkipExtraLetters(t *testing.T) {
+	m := pressKeys(newStrictTestModel("the cat", "", "", errorModeSkip), "thee cat")
+	if m.typedText != "the cat" || !m.completed {
+		t.Fatalf("typedText = %q, completed = %v, want the extra letter dropped", m.typedText, m.completed)
+	}
+	if m.errors != 1 {
+		t.Errorf("errors = %d, want 1 for the extra letter", m.errors)
+	}
+	result := metrics.Compute(m.keystrokes, m.typedText, m.text, time.Minute)
+	if result.UncorrectedErrors != 0 || result.Errors != 1 {
+		t.Errorf("Compute() errors = %d, uncorrected = %d, want 1 and 0", result.Errors, result.UncorrectedErrors)
+	}
+}
+
+func TestSessionModel_SkipWord(t *testing.T) {
+	text := "the cat sat"
+	m := pressKeys(newStrictTestModel(text, "", "", errorModeSkip), "the c sat")
+	if !m.completed {
+		t.Fatal("completed = false, want the early space to skip to the next word")
+	}
+	if want := "the c" + strings.Repeat(skippedChar, 2) + " sat"; m.typedText != want {
+		t.Errorf("typedText = %q, want %q", m.typedText, want)
+	}
+	if m.errors != 1 {
+		t.Errorf("errors = %d, want 1 for the skip", m.errors)
+	}
+
+	// The early space is recorded as one miss at the first skipped letter
+	miss := m.keystrokes[5]
+	if miss.Pos != 5 || miss.Expected != 'a' || miss.Typed != ' ' {
+		t.Errorf("skip keystroke = %+v, want a space typed for 'a' at 5", miss)
+	}
+
+	// Skipped letters stay wrong in the final text
+	result := metrics.Compute(m.keystrokes, m.typedText, m.text, time.Minute)
+	if result.UncorrectedErrors != 2 {
+		t.Errorf("Compute() uncorrected = %d, want 2 skipped letters", result.UncorrectedErrors)
+	}
+
+	// Skipped letters were never typed, so they add no speed and the one miss
+	// is measured against the 9 keys actually typed
+	if want := float32(9) / 5; result.GrossWPM != want {
+		t.Errorf("Compute() gross WPM = %v, want %v", result.GrossWPM, want)
+	}
+	if want := float32(8) / 9 * 100; m.getCurrentAccuracy() != want {
+		t.Errorf("getCurrentAccuracy() = %v, want %v", m.getCurrentAccuracy(), want)
+	}
+}
+
+func TestSessionModel_SkipLastWord(t *testing.T) {
+	// There is no later word to skip to, so the space is an ordinary error
+	m := pressKeys(newStrictTestModel("the cat", "", "", errorModeSkip), "the c ")
+	if m.typedText != "the c " {
+		t.Errorf("typedText = %q, want %q", m.typedText, "the c ")
+	}
+}
+
+func TestStrictnessLabel(t *testing.T) {
+	tests := []struct {
+		backspace string
+		errorMode string
+		want      string
+	}{
+		{"", "", ""},
+		{backspaceDisabled, "", "Strict: no backspace"},
+		{backspaceWord, errorModeSkip, "Strict: backspace within word • errors skipped"},
+		{backspaceDisabled, errorModeFixWord, "Strict: backspace within word • fix every word"},
+	}
+	for _, tt := range tests {
+		m := newStrictTestModel("text", "", tt.backspace, tt.errorMode)
+		if got := m.strictnessLabel(); got != tt.want {
+			t.Errorf("strictnessLabel() with %q/%q = %q, want %q", tt.backspace, tt.errorMode, got, tt.want)
+		}
+	}
+}
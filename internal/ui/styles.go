@@ -7,6 +7,7 @@ type Theme struct {
 	Correct      lipgloss.Style
 	Incorrect    lipgloss.Style
 	Current      lipgloss.Style
+	PaceCaret    lipgloss.Style
 	Normal       lipgloss.Style
 	Background   lipgloss.Style
 	Title        lipgloss.Style
@@ -31,6 +32,9 @@ var (
 		Current: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("0")).
 			Background(lipgloss.Color("6")), // Black text on cyan highlight
+		PaceCaret: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("7")).
+			Background(lipgloss.Color("8")), // Light grey on dark grey, quieter than the cursor
 		Normal: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("7")), // Terminal white/default
 		Background: lipgloss.NewStyle().
@@ -74,6 +78,9 @@ var (
 		Current: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("0")).
 			Background(lipgloss.Color("11")), // Black on bright terminal yellow
+		PaceCaret: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("15")).
+			Background(lipgloss.Color("5")), // Bright white on magenta
 		Normal: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("15")), // Bright terminal white
 		Background: lipgloss.NewStyle().
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"runtime/debug"
+
+	"go-touch/internal/sources"
+	"go-touch/internal/types"
+)
+
+// sourceDrill is recorded as the source of drill sessions, whose text comes from mistyped words
+const sourceDrill = "drill"
+
+// appVersion returns the module version of the build, or the VCS revision for
+// builds from a checkout
+func appVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "dev"
+	}
+	if info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+
+	var revision, modified string
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			if setting.Value == "true" {
+				modified = "-dirty"
+			}
+		}
+	}
+	if len(revision) > 7 {
+		revision = revision[:7]
+	}
+	if revision == "" {
+		return "dev"
+	}
+	return revision + modified
+}
+
+// textHash identifies a text by the first 12 hex digits of its SHA-256
+func textHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// tagSession records what the session was typed with, so history can be filtered
+func (m sessionModel) tagSession(session *types.TypingSession) {
+	session.Source = m.source
+	if m.drill {
+		session.Source = sourceDrill
+	}
+	if session.Source == sources.SourceLLM {
+		session.Provider = m.config.Text.LLM.Provider
+		session.Model = m.config.Text.LLM.Model
+	}
+	if session.Mode == types.ModeTime {
+		session.TargetDuration = m.sessionDuration
+	}
+	session.KeyboardLayout = m.keyboardLayout().Name
+	session.Theme = m.config.Ui.Theme
+	session.AppVersion = appVersion()
+	session.TextHash = textHash(m.text)
+}
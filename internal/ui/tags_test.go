@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"go-touch/internal/sources"
+	"go-touch/internal/types"
+	"testing"
+	"time"
+)
+
+func TestTextHash(t *testing.T) {
+	if textHash("the cat") != textHash("the cat") {
+		t.Error("textHash() differs for the same text")
+	}
+	if textHash("the cat") == textHash("the dog") {
+		t.Error("textHash() is the same for different texts")
+	}
+	if got := len(textHash("")); got != 12 {
+		t.Errorf("textHash() has %d characters, want 12", got)
+	}
+}
+
+func TestTagSession(t *testing.T) {
+	config := types.Config{}
+	config.Text.LLM.Provider = "anthropic"
+	config.Text.LLM.Model = "claude-3-5-haiku-latest"
+	config.Ui.Theme = "dark"
+
+	tests := []struct {
+		name         string
+		source       string
+		drill        bool
+		mode         types.TestMode
+		wantSource   string
+		wantModel    string
+		wantDuration time.Duration
+	}{
+		{"llm time", sources.SourceLLM, false, types.ModeTime, "llm", "claude-3-5-haiku-latest", 2 * time.Minute},
+		{"dummy words", sources.SourceDummy, false, types.ModeWords, "dummy", "", 0},
+		{"drill", "", true, types.ModeText, sourceDrill, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := sessionModel{config: config, source: tt.source, drill: tt.drill, text: "the cat", sessionDuration: 2 * time.Minute}
+			session := types.TypingSession{Mode: tt.mode}
+			m.tagSession(&session)
+
+			if session.Source != tt.wantSource || session.Model != tt.wantModel || session.TargetDuration != tt.wantDuration {
+				t.Errorf("tagSession() source %q, model %q, duration %v, want %q, %q, %v",
+					session.Source, session.Model, session.TargetDuration, tt.wantSource, tt.wantModel, tt.wantDuration)
+			}
+			if session.KeyboardLayout != "QWERTY" || session.Theme != "dark" {
+				t.Errorf("tagSession() layout %q, theme %q, want QWERTY and dark", session.KeyboardLayout, session.Theme)
+			}
+			if session.AppVersion == "" || session.TextHash != textHash("the cat") {
+				t.Errorf("tagSession() version %q, text hash %q, want both set", session.AppVersion, session.TextHash)
+			}
+		})
+	}
+}
@@ -1,11 +1,15 @@
 package ui
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
+	"go-touch/internal/keyboard"
+	"go-touch/internal/metrics"
 	"go-touch/internal/sources"
+	"go-touch/internal/srs"
+	"go-touch/internal/stats"
 	"go-touch/internal/types"
+	"math/rand"
 	"os"
 	"strings"
 	"time"
@@ -14,54 +18,6 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-func getUserStats(config types.Config) (types.UserStats, error) {
-	var stats types.UserStats
-	_, err := os.Stat(config.Stats.FileDir)
-	if err != nil {
-		// returning empty if file not exists
-		return stats, nil
-	}
-	data, err := os.ReadFile(config.Stats.FileDir)
-	if err != nil {
-		return stats, err
-	}
-	// Handle empty file
-	if len(data) == 0 || len(strings.TrimSpace(string(data))) == 0 {
-		stats.Sessions = make([]types.TypingSession, 0)
-		return stats, nil
-	}
-
-	err = json.Unmarshal(data, &stats)
-	if err != nil {
-		return stats, err
-	}
-
-	// Ensure Sessions is never nil
-	if stats.Sessions == nil {
-		stats.Sessions = make([]types.TypingSession, 0)
-	}
-
-	return stats, err
-
-}
-
-func saveUserStats(config types.Config, stats types.UserStats) error {
-	// Ensure the directory exists
-	// Marshal to pretty JSON
-	data, err := json.MarshalIndent(stats, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	// Write to file
-	err = os.WriteFile(config.Stats.FileDir, data, 0644)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
 // analyzeErrors compares typed text with target text and returns error patterns
 func analyzeErrors(typed, target string) (errorChars []rune, problemWords []string) {
 	errorCharMap := make(map[rune]int)
@@ -131,6 +87,7 @@ type WelcomeAction int
 const (
 	StartSession WelcomeAction = iota
 	Exit
+	History
 )
 
 func (w WelcomeAction) String() string {
@@ -139,6 +96,8 @@ func (w WelcomeAction) String() string {
 		return "Lets Exercise"
 	case Exit:
 		return "Naah not today lets exit"
+	case History:
+		return "Show me how I'm doing"
 	default:
 		return "Unknown Action"
 	}
@@ -150,7 +109,10 @@ type welcomeModel struct {
 	cursor   int
 	selected *WelcomeAction // stores the selected action
 	choices  []WelcomeAction
-	done     bool // indicates user made a selection
+	done     bool         // indicates user made a selection
+	notice   string       // warning shown above the menu, e.g. after recovering the stats file
+	goals    goalProgress // daily goals, streaks and practice calendar
+	height   int          // terminal height, 0 until known
 }
 
 func newWelcomeModel(config types.Config, stats types.UserStats) welcomeModel {
@@ -158,8 +120,10 @@ func newWelcomeModel(config types.Config, stats types.UserStats) welcomeModel {
 		config: config,
 		stats:  stats,
 		cursor: 0,
+		goals:  newGoalProgress(config.Goals, stats, time.Now()),
 		choices: []WelcomeAction{
 			StartSession,
+			History,
 			Exit,
 		},
 	}
@@ -170,6 +134,16 @@ func (m welcomeModel) Init() tea.Cmd {
 }
 
 func (m welcomeModel) View() string {
+	view := m.render(true)
+	// Leave out the practice calendar when the terminal is too short for it
+	if m.height > 0 && lipgloss.Height(view) > m.height {
+		view = m.render(false)
+	}
+	return view
+}
+
+// render draws the welcome screen, with or without the practice calendar
+func (m welcomeModel) render(calendar bool) string {
 	var s strings.Builder
 
 	// ASCII Art Logo
@@ -218,6 +192,22 @@ func (m welcomeModel) View() string {
 		s.WriteString("\n\n")
 	}
 
+	// Goals, streaks and the practice calendar
+	if len(m.stats.Sessions) > 0 || m.config.Goals != (types.GoalsConfig{}) {
+		s.WriteString(lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("8")).
+			Padding(0, 1).
+			Width(60).
+			Render(m.goals.render(calendar)))
+		s.WriteString("\n\n")
+	}
+
+	if m.notice != "" {
+		s.WriteString(lipgloss.NewStyle().Width(60).Render(DefaultTheme.Warning.Render(m.notice)))
+		s.WriteString("\n\n")
+	}
+
 	// Menu options with better styling
 	menuBox := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
@@ -262,6 +252,9 @@ func (m welcomeModel) View() string {
 
 func (m welcomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "q":
@@ -290,8 +283,9 @@ func (m welcomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func showWelcome(config types.Config, stats types.UserStats) (WelcomeAction, error) {
+func showWelcome(config types.Config, stats types.UserStats, notice string) (WelcomeAction, error) {
 	model := newWelcomeModel(config, stats)
+	model.notice = notice
 
 	program := tea.NewProgram(model, tea.WithAltScreen())
 	finalModel, err := program.Run()
@@ -314,6 +308,13 @@ type dashboardModel struct {
 	allStats       types.UserStats
 	width          int
 	height         int
+
+	keyboard       *keyboard.Layout // layout the heatmap is drawn on
+	ngrams         types.NGramStats // rolling n-gram timings including this session
+	heatmapMetric  heatmapMetric    // error rate or latency
+	heatmapHistory bool             // show the whole history instead of this session
+	tab            dashboardTab     // summary or error review
+	drill          bool             // user asked to drill the mistyped words
 }
 
 func newDashboardModel(config types.Config, session types.TypingSession, stats types.UserStats) dashboardModel {
@@ -336,9 +337,29 @@ func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		// Only Enter key exits the dashboard
-		if msg.String() == "enter" {
+		switch msg.String() {
+		case "enter":
+			// Only Enter key exits the dashboard
 			return m, tea.Quit
+		case "left", "right":
+			// Switch between the summary and the error review
+			if m.tab == dashboardSummary {
+				m.tab = dashboardReview
+			} else {
+				m.tab = dashboardSummary
+			}
+		case "d":
+			// Drill the mistyped words in a short follow-up session
+			if m.tab == dashboardReview && len(m.currentSession.MistypedWords) > 0 {
+				m.drill = true
+				return m, tea.Quit
+			}
+		case "tab":
+			// Switch the heatmap between error rate and latency
+			m.heatmapMetric = (m.heatmapMetric + 1) % heatmapMetricCount
+		case "h":
+			// Switch the heatmap between this session and the whole history
+			m.heatmapHistory = !m.heatmapHistory
 		}
 	}
 
@@ -362,10 +383,26 @@ func (m dashboardModel) View() string {
 		Align(lipgloss.Center).
 		Width(termWidth - 4)
 
-	title := titleStyle.Render(DefaultTheme.Title.Render("SESSION COMPLETE!"))
+	previousBest, hadBest, isNewBest := m.personalBest()
+	heading := "SESSION COMPLETE!"
+	if isNewBest {
+		heading = "NEW PERSONAL BEST!"
+	}
+	title := titleStyle.Render(DefaultTheme.Title.Render(heading))
 	s.WriteString(title)
 	s.WriteString("\n\n")
 
+	s.WriteString(lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(termWidth).
+		Render(m.renderTabs()))
+	s.WriteString("\n\n")
+
+	if m.tab == dashboardReview {
+		s.WriteString(m.renderReview(termWidth))
+		return s.String()
+	}
+
 	// Stat box style
 	statBoxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -409,6 +446,28 @@ func (m dashboardModel) View() string {
 	s.WriteString(perfTitle)
 	s.WriteString("\n\n")
 
+	// Mode and its personal best among sessions of the same mode, duration or
+	// word count and source
+	modeLine := fmt.Sprintf("%s: %s", DefaultTheme.Muted.Render("Mode"), SessionLabel(m.currentSession))
+	if m.currentSession.Source != "" {
+		modeLine += " • " + m.currentSession.Source
+	}
+	switch {
+	case !hadBest:
+		modeLine += " | " + DefaultTheme.Muted.Render("First run of its kind, this is the best to beat")
+	case isNewBest:
+		modeLine += fmt.Sprintf(" | %s: %.0f WPM %s", DefaultTheme.Muted.Render("Previous Best"), previousBest,
+			DefaultTheme.Highlight.Render(fmt.Sprintf("New personal best! +%.1f WPM", m.currentSession.WPM-previousBest)))
+	default:
+		modeLine += fmt.Sprintf(" | %s: %.0f WPM (%+.1f)", DefaultTheme.Muted.Render("Personal Best"), previousBest,
+			m.currentSession.WPM-previousBest)
+	}
+	s.WriteString(lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(termWidth).
+		Render(modeLine))
+	s.WriteString("\n\n")
+
 	// Arrange stat boxes horizontally or vertically based on width
 	if termWidth >= 90 {
 		// Wide layout: all boxes in one row
@@ -432,11 +491,33 @@ func (m dashboardModel) View() string {
 			Render(row2))
 	}
 
+	// Detailed speed and error breakdown (absent on sessions saved before it existed)
+	if m.currentSession.RawWPM > 0 {
+		details := fmt.Sprintf("%s: %.0f | %s: %.0f | %s: %.0f%% | %s: %d | %s: %d",
+			DefaultTheme.Muted.Render("Gross WPM"), m.currentSession.GrossWPM,
+			DefaultTheme.Muted.Render("Raw WPM"), m.currentSession.RawWPM,
+			DefaultTheme.Muted.Render("Consistency"), m.currentSession.Consistency,
+			DefaultTheme.Muted.Render("Corrected"), m.currentSession.CorrectedErrors,
+			DefaultTheme.Muted.Render("Uncorrected"), m.currentSession.UncorrectedErrors,
+		)
+		s.WriteString("\n\n")
+		s.WriteString(lipgloss.NewStyle().
+			Align(lipgloss.Center).
+			Width(termWidth).
+			Render(details))
+	}
+
 	s.WriteString("\n\n")
 
-	// Historical stats section
-	if len(m.allStats.Sessions) > 0 {
-		avgWPM, bestWPM, avgAccuracy := calculateHistoricalStats(m.allStats)
+	// Historical stats section, compared with sessions typed the same way so a
+	// short dummy run is not averaged with a long LLM run
+	comparable := stats.Comparable(m.allStats, m.currentSession)
+	if len(comparable.Sessions) > 0 {
+		avgWPM, bestWPM, avgAccuracy := calculateHistoricalStats(comparable)
+		histLabel := SessionLabel(m.currentSession)
+		if m.currentSession.Source != "" {
+			histLabel += " • " + m.currentSession.Source
+		}
 
 		// Historical stats title
 		histTitle := lipgloss.NewStyle().
@@ -446,7 +527,7 @@ func (m dashboardModel) View() string {
 			Padding(0, 2).
 			Align(lipgloss.Center).
 			Width(termWidth - 4).
-			Render(DefaultTheme.Info.Render("Historical Stats"))
+			Render(DefaultTheme.Info.Render("Historical Stats") + DefaultTheme.Muted.Render(" • "+histLabel))
 
 		s.WriteString(histTitle)
 		s.WriteString("\n\n")
@@ -471,7 +552,7 @@ func (m dashboardModel) View() string {
 		sessionsBox := statBoxStyle.Copy().
 			Render(fmt.Sprintf("%s\n\n%s",
 				DefaultTheme.Muted.Render("Sessions"),
-				fmt.Sprintf("%d", len(m.allStats.Sessions))))
+				fmt.Sprintf("%d", len(comparable.Sessions))))
 
 		// Arrange historical stat boxes
 		if termWidth >= 90 {
@@ -497,6 +578,30 @@ func (m dashboardModel) View() string {
 		s.WriteString("\n\n")
 	}
 
+	// Learning curve trend, plateau and target projection
+	if insights := m.renderInsights(termWidth); insights != "" {
+		s.WriteString(insights)
+		s.WriteString("\n\n")
+	}
+
+	// Finger and hand breakdown of this session's errors
+	if analysis := m.renderFingerAnalysis(termWidth); analysis != "" {
+		s.WriteString(analysis)
+		s.WriteString("\n\n")
+	}
+
+	// Slowest bigrams and trigrams
+	if slow := m.renderSlowNGrams(termWidth); slow != "" {
+		s.WriteString(slow)
+		s.WriteString("\n\n")
+	}
+
+	// Per-key heatmap, absent on sessions saved before key stats existed
+	if heatmap := m.renderHeatmapPanel(termWidth); heatmap != "" {
+		s.WriteString(heatmap)
+		s.WriteString("\n\n")
+	}
+
 	// Encouraging message based on performance
 	var message string
 	if m.currentSession.Accuracy >= 95 && m.currentSession.WPM >= 50 {
@@ -560,11 +665,17 @@ func calculateHistoricalStats(stats types.UserStats) (avgWPM, bestWPM, avgAccura
 	return avgWPM, bestWPM, avgAccuracy
 }
 
-func showDashboard(config types.Config, session types.TypingSession, stats types.UserStats) error {
+// showDashboard shows the results and reports whether the user asked to drill the mistyped words
+func showDashboard(config types.Config, session types.TypingSession, stats types.UserStats, layout *keyboard.Layout, ngrams types.NGramStats) (bool, error) {
 	model := newDashboardModel(config, session, stats)
+	model.keyboard = layout
+	model.ngrams = ngrams
 	program := tea.NewProgram(model, tea.WithAltScreen())
-	_, err := program.Run()
-	return err
+	finalModel, err := program.Run()
+	if err != nil {
+		return false, err
+	}
+	return finalModel.(dashboardModel).drill, nil
 }
 
 type sessionModel struct {
@@ -572,41 +683,65 @@ type sessionModel struct {
 	typedText        string
 	errors           int
 	cursor           int
-	startTime        time.Time       // when session started
-	lastKeyTime      time.Time       // last keystroke time
-	keyStrokeTimes   []time.Duration // time per word
-	completed        bool            // session finished
-	quit             bool            // user quit early
+	startTime        time.Time           // when session started
+	lastKeyTime      time.Time           // last keystroke time
+	keyStrokeTimes   []time.Duration     // time per word
+	keystrokes       []metrics.Keystroke // full keystroke log for final metrics
+	completed        bool                // session finished
+	quit             bool                // user quit early
 	hasStarted       bool
-	width            int           // terminal width
-	height           int           // terminal height
-	viewportOffset   int           // horizontal scroll position for centered cursor
-	sessionDuration  time.Duration // total session duration
-	selectedDuration int           // selected duration in minutes (for setup UI)
+	width            int            // terminal width
+	height           int            // terminal height
+	viewportOffset   int            // horizontal scroll position for centered cursor
+	sessionDuration  time.Duration  // total session duration
+	selectedDuration time.Duration  // selected duration (for setup UI)
+	customDuration   string         // duration being typed on the setup screen, e.g. "45s"
+	durationErr      string         // validation error for the typed duration
+	mode             types.TestMode // selected test mode (end condition)
+	wordCount        int            // target word count in words mode
+
+	// Pause tracking; paused time is excluded from duration and WPM
+	paused      bool          // session clock is stopped
+	pausedAt    time.Time     // when the current pause began
+	pauseIsAuto bool          // current pause was triggered by idle detection
+	pauses      []types.Pause // finished pauses
+	idleLimit   time.Duration // inactivity before auto-pause (0 disables)
+
+	keyboard     *keyboard.Layout // physical key positions and finger assignments
+	drill        bool             // drill of mistyped words, starts on the first key without the configuration screen
+	deckPath     string           // spaced repetition deck file updated when the session ends (empty to skip)
+	ngramPath    string           // rolling n-gram stats file updated when the session ends (empty to skip)
+	showKeyboard bool             // on-screen keyboard toggled on
+	wrongKey     rune             // last mistyped character, marked on the keyboard
+	wrongKeyTime time.Time        // when wrongKey was typed
+	paceWPM      float32          // speed of the pace caret (0 hides it)
 
 	// LLM pregeneration fields
-	isLLMSource           bool               // Flag for LLM mode
-	llmSource             *sources.LLMSource // LLM source for generating text
-	lastSentence          string             // Previous sentence for context
-	errorPatterns         map[rune]int       // Track char error frequency
-	problemWords          []string           // Words with mistakes (accumulated for LLM)
-	currentProblemWords   []string           // Words mistyped in current sentence (for display)
-	wordsWithErrors       map[int]bool       // Track word positions that had any errors
-	lastWordEnd           int                // Track last completed word position
-	generationPending     bool               // Is LLM call in progress?
-	nextSentenceReady     bool               // Next sentence generated?
-	nextSentenceBuffer    string             // Buffered next sentence
-	generationChan        chan string        // Channel for async generation
-	generationErrChan     chan error         // Channel for generation errors
-	pregenerateThreshold  int                // Chars before end to trigger
-	currentSentenceEndPos int                // Position where current sentence ends
-	config                types.Config       // Config for LLM settings
+	source                string                 // Type name of the text source, see sources.SourceName
+	isAdaptiveSource      bool                   // Source generates follow-up text (LLM or practice)
+	adaptiveSource        sources.AdaptiveSource // Source generating the follow-up sentences
+	ngramHistory          types.NGramStats       // Rolling n-gram timings from earlier sessions
+	difficultyLevel       int                    // Index into sources.DifficultyLevels for the next sentence
+	lastSentence          string                 // Previous sentence for context
+	errorPatterns         map[rune]int           // Track char error frequency
+	problemWords          []string               // Words with mistakes (accumulated for LLM)
+	currentProblemWords   []string               // Words mistyped in current sentence (for display)
+	wordsWithErrors       map[int]bool           // Track word positions that had any errors
+	lastWordEnd           int                    // Track last completed word position
+	generationPending     bool                   // Is LLM call in progress?
+	nextSentenceReady     bool                   // Next sentence generated?
+	nextSentenceBuffer    string                 // Buffered next sentence
+	generationChan        chan string            // Channel for async generation
+	generationErrChan     chan error             // Channel for generation errors
+	pregenerateThreshold  int                    // Chars before end to trigger
+	currentSentenceEndPos int                    // Position where current sentence ends
+	config                types.Config           // Config for LLM settings
 
 	// Typo blocking fields
-	hasTypo           bool      // True if current character is a typo
-	typoFlashTime     time.Time // Time when typo flash started
-	currentWordIndex  int       // Index of current word being typed (0-based)
-	targetWords       []string  // Target text split into words for easier comparison
+	hasTypo          bool      // True if current character is a typo
+	typoFlashTime    time.Time // Time when typo flash started
+	currentWordIndex int       // Index of current word being typed (0-based)
+	targetWords      []string  // Target text split into words for easier comparison
 }
 
 func (m sessionModel) Init() tea.Cmd {
@@ -642,6 +777,19 @@ func typoFlashCmd(duration time.Duration) tea.Cmd {
 	})
 }
 
+// flashTypo starts the typo flash if enabled
+func (m *sessionModel) flashTypo() tea.Cmd {
+	if !m.config.Ui.TypoFlashEnabled {
+		return nil
+	}
+	m.typoFlashTime = time.Now()
+	flashDuration := time.Duration(m.config.Ui.TypoFlashDurationMs) * time.Millisecond
+	if flashDuration <= 0 {
+		flashDuration = 200 * time.Millisecond // Default 200ms
+	}
+	return typoFlashCmd(flashDuration)
+}
+
 // checkForMistypedWord checks if the user just completed a word and if it was mistyped
 func (m *sessionModel) checkForMistypedWord() {
 	typedLen := len(m.typedText)
@@ -733,9 +881,17 @@ func (m sessionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tickMsg:
+		// Auto-pause when the user has been inactive too long
+		if m.hasStarted && !m.completed && !m.quit && !m.paused && m.idleLimit > 0 {
+			if time.Since(m.lastKeyTime) >= m.idleLimit {
+				// Backdate to the last keystroke so the idle time is not counted
+				m.pause(m.lastKeyTime, true)
+			}
+		}
+
 		// Check if session time has expired
-		if m.hasStarted && !m.completed && !m.quit {
-			elapsed := time.Since(m.startTime)
+		if m.hasStarted && !m.completed && !m.quit && !m.paused && m.activeMode() == types.ModeTime {
+			elapsed := m.activeElapsed(time.Now())
 			if elapsed >= m.sessionDuration {
 				m.completed = true
 				return m, tea.Quit
@@ -743,11 +899,12 @@ func (m sessionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Check for pregeneration trigger
-		if m.isLLMSource && m.hasStarted && !m.generationPending && !m.nextSentenceReady {
+		if m.continuesText() && m.hasStarted && !m.generationPending && !m.nextSentenceReady {
 			charsRemaining := m.currentSentenceEndPos - len(m.typedText)
 			if charsRemaining <= m.pregenerateThreshold && charsRemaining > 0 {
 				// Start async generation
 				m.generationPending = true
+				m.adjustDifficulty()
 				return m, tea.Batch(tickCmd(), m.generateNextSentenceCmd())
 			}
 		}
@@ -776,28 +933,75 @@ func (m sessionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		// Any key but the exit keys resumes a paused session without being
+		// typed, before backspace or any other key is handled
+		if m.paused && msg.String() != "esc" && msg.String() != "ctrl+c" {
+			m.resume(time.Now())
+			return m, nil
+		}
+
 		// Exit keys
 		switch msg.String() {
 		case "esc", "ctrl+c":
+			// Zen mode has no end condition, so ESC finishes the run instead of discarding it
+			if msg.String() == "esc" && m.hasStarted && m.activeMode() == types.ModeZen {
+				m.resume(time.Now())
+				m.completed = true
+				return m, tea.Quit
+			}
 			m.quit = true
 			return m, tea.Quit
 
+		case "ctrl+p":
+			// Pause during the session; any key resumes
+			if m.hasStarted {
+				m.pause(time.Now(), false)
+				return m, nil
+			}
+
+		case "ctrl+k":
+			// Toggle the on-screen keyboard
+			m.showKeyboard = !m.showKeyboard
+			return m, nil
+
+		case "left", "right":
+			// Change test mode before session starts; drills are always text mode
+			if !m.hasStarted && !m.drill {
+				delta := 1
+				if msg.String() == "left" {
+					delta = -1
+				}
+				m.mode = cycleMode(m.activeMode(), delta)
+				// A custom duration only belongs to time mode
+				m.customDuration = ""
+				m.durationErr = ""
+				return m, nil
+			}
+
 		case "up":
-			// Increase duration before session starts
+			// Increase duration or word count before session starts
 			if !m.hasStarted {
-				m.selectedDuration++
-				if m.selectedDuration > 60 { // Max 60 minutes
-					m.selectedDuration = 60
+				switch m.activeMode() {
+				case types.ModeTime:
+					m.selectedDuration = stepDuration(m.selectedDuration, 1)
+					m.customDuration = ""
+					m.durationErr = ""
+				case types.ModeWords:
+					m.wordCount = cycleWordCount(m.targetWordCount(), 1)
 				}
 				return m, nil
 			}
 
 		case "down":
-			// Decrease duration before session starts
+			// Decrease duration or word count before session starts
 			if !m.hasStarted {
-				m.selectedDuration--
-				if m.selectedDuration < 1 { // Min 1 minute
-					m.selectedDuration = 1
+				switch m.activeMode() {
+				case types.ModeTime:
+					m.selectedDuration = stepDuration(m.selectedDuration, -1)
+					m.customDuration = ""
+					m.durationErr = ""
+				case types.ModeWords:
+					m.wordCount = cycleWordCount(m.targetWordCount(), -1)
 				}
 				return m, nil
 			}
@@ -805,24 +1009,57 @@ func (m sessionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 			// Start the session on first enter press
 			if !m.hasStarted {
-				m.hasStarted = true
-				m.sessionDuration = time.Duration(m.selectedDuration) * time.Minute
-				m.startTime = time.Now()
-				m.lastKeyTime = m.startTime
+				// Apply a typed custom duration first, refusing to start on invalid input
+				if m.activeMode() == types.ModeTime && m.customDuration != "" {
+					duration, err := parseCustomDuration(m.customDuration)
+					if err != nil {
+						m.durationErr = err.Error()
+						return m, nil
+					}
+					m.selectedDuration = duration
+					m.customDuration = ""
+					m.durationErr = ""
+				}
+				m.begin(time.Now())
 				return m, nil
 			}
 
 		case "backspace":
+			if !m.hasStarted && m.customDuration != "" {
+				m.customDuration = m.customDuration[:len(m.customDuration)-1]
+				m.durationErr = ""
+				return m, nil
+			}
 			if len(m.typedText) > 0 {
+				if m.hasStarted && !m.canBackspace() {
+					return m, nil
+				}
 				m.typedText = m.typedText[:len(m.typedText)-1]
+				if m.hasStarted {
+					m.keystrokes = append(m.keystrokes, metrics.Keystroke{
+						At:        m.activeElapsed(time.Now()),
+						Pos:       len(m.typedText),
+						Backspace: true,
+					})
+				}
 				// Clear typo flag when user deletes the incorrect character
 				m.hasTypo = false
 			}
 			return m, nil
 		}
 
+		// Drills skip the configuration screen and start with the first typed character
+		if !m.hasStarted && m.drill && (len(msg.String()) == 1 || msg.String() == "space") {
+			m.begin(time.Now())
+		}
+
 		// Only process character input if session has started
 		if !m.hasStarted {
+			// Digits and unit suffixes enter a custom duration in time mode
+			if m.activeMode() == types.ModeTime && isCustomDurationKey(msg.String()) {
+				m.customDuration += msg.String()
+				m.durationErr = ""
+			}
 			return m, nil
 		}
 
@@ -836,26 +1073,66 @@ func (m sessionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			// If typo blocking is enabled and we have a typo, don't allow input
-			if m.config.Ui.BlockOnTypo && m.hasTypo {
+			// (unless backspace is disabled, which would leave no way out)
+			if m.config.Ui.BlockOnTypo && m.hasTypo && m.backspaceMode() != backspaceDisabled {
 				return m, nil
 			}
 
+			currentTime := time.Now()
+
+			// Strict error modes may refuse the key or pass over the rest of the word
+			skipFrom := len(m.typedText)
+			switch m.errorMode() {
+			case errorModeFixWord:
+				if m.leavesWrongWord(key) {
+					m.lastKeyTime = currentTime
+					return m, m.flashTypo()
+				}
+			case errorModeSkip:
+				if m.isExtraLetter(key) {
+					m.recordExtraLetter(key, currentTime)
+					if m.activeMode() == types.ModeSuddenDeath {
+						m.completed = true
+						return m, tea.Quit
+					}
+					return m, m.flashTypo()
+				}
+				m.typedText += m.skipPadding(key)
+			}
+
 			// Add the character to typed text
 			m.typedText += key
 
 			// Track timing
-			currentTime := time.Now()
 			m.keyStrokeTimes = append(m.keyStrokeTimes, currentTime.Sub(m.lastKeyTime))
 			m.lastKeyTime = currentTime
+			keystroke := m.newKeystroke(currentTime)
+			if skipped := len(m.typedText) - 1 - skipFrom; skipped > 0 {
+				// The early space is one miss, at the first skipped letter
+				keystroke.Pos = skipFrom
+				keystroke.Expected = rune(m.text[skipFrom])
+				for i := skipFrom; i < skipFrom+skipped; i++ {
+					m.wordsWithErrors[i] = true
+				}
+			}
+			m.keystrokes = append(m.keystrokes, keystroke)
 
 			// Check if character is incorrect
 			isError := false
-			if len(m.typedText) <= len(m.text) {
-				if m.typedText[len(m.typedText)-1] != m.text[len(m.typedText)-1] {
+			if keystroke.Expected != 0 {
+				if !keystroke.Correct() {
 					m.errors++
 					isError = true
 					// Mark current word position as having errors
-					m.wordsWithErrors[len(m.typedText)-1] = true
+					m.wordsWithErrors[keystroke.Pos] = true
+					m.wrongKey = keystroke.Typed
+					m.wrongKeyTime = currentTime
+
+					// Sudden death: the first error ends the run
+					if m.activeMode() == types.ModeSuddenDeath {
+						m.completed = true
+						return m, tea.Quit
+					}
 
 					// Set typo flag and trigger flash if enabled
 					if m.config.Ui.BlockOnTypo {
@@ -864,12 +1141,7 @@ func (m sessionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 					// Trigger visual flash effect
 					if m.config.Ui.TypoFlashEnabled {
-						m.typoFlashTime = time.Now()
-						flashDuration := time.Duration(m.config.Ui.TypoFlashDurationMs) * time.Millisecond
-						if flashDuration <= 0 {
-							flashDuration = 200 * time.Millisecond // Default 200ms
-						}
-						return m, typoFlashCmd(flashDuration)
+						return m, m.flashTypo()
 					}
 				}
 			}
@@ -882,10 +1154,16 @@ func (m sessionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Check if we just completed a word and if it was mistyped
 			m.checkForMistypedWord()
 
+			// Words mode: end once the target number of words has been typed
+			if m.activeMode() == types.ModeWords && m.wordsTyped() >= m.targetWordCount() {
+				m.completed = true
+				return m, tea.Quit
+			}
+
 			// Check if completed current sentence
 			if len(m.typedText) >= m.currentSentenceEndPos {
-				if m.isLLMSource {
-					// LLM mode: transition to next sentence if ready
+				if m.continuesText() {
+					// Adaptive source: transition to next sentence if ready
 					if m.nextSentenceReady {
 						// Analyze errors from the sentence just completed
 						errorChars, problemWords := analyzeErrors(m.typedText[:m.currentSentenceEndPos], m.text[:m.currentSentenceEndPos])
@@ -929,26 +1207,49 @@ func (m sessionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// begin starts the session clock
+func (m *sessionModel) begin(now time.Time) {
+	m.hasStarted = true
+	m.fillWords()
+	m.sessionDuration = m.selectedDuration
+	m.startTime = now
+	m.lastKeyTime = now
+}
+
+// newKeystroke records the character just appended to typedText
+func (m sessionModel) newKeystroke(at time.Time) metrics.Keystroke {
+	pos := len(m.typedText) - 1
+	keystroke := metrics.Keystroke{
+		At:    m.activeElapsed(at),
+		Pos:   pos,
+		Typed: rune(m.typedText[pos]),
+	}
+	if pos < len(m.text) {
+		keystroke.Expected = rune(m.text[pos])
+	}
+	return keystroke
+}
+
 // getCurrentWPM calculates WPM based on current typing progress
 func (m sessionModel) getCurrentWPM() float32 {
 	if !m.hasStarted {
 		return 0
 	}
 
-	elapsed := time.Since(m.startTime)
+	elapsed := m.activeElapsed(time.Now())
 	if elapsed.Seconds() < 1 {
 		return 0 // Avoid division by very small numbers
 	}
 
 	// Calculate based on characters typed so far
-	words := float32(len(m.typedText)) / 5.0
+	words := float32(metrics.TypedChars(m.typedText)) / 5.0
 	minutes := float32(elapsed.Minutes())
 	return words / minutes
 }
 
 // getCurrentAccuracy calculates accuracy percentage based on current progress
 func (m sessionModel) getCurrentAccuracy() float32 {
-	totalChars := len(m.typedText)
+	totalChars := metrics.TypedChars(m.typedText)
 	if totalChars == 0 {
 		return 0
 	}
@@ -957,17 +1258,29 @@ func (m sessionModel) getCurrentAccuracy() float32 {
 	return (float32(correctChars) / float32(totalChars)) * 100
 }
 
+// practiceHints summarises the errors made so far for adaptive text generation
+func (m sessionModel) practiceHints() sources.PracticeHints {
+	confusions := metrics.Confusions(m.keystrokes)
+	analysis := metrics.AnalyzeFingers(m.keyboardLayout(), metrics.KeyStats(m.keystrokes), confusions)
+	hints := sources.HintsFromAnalysis(confusions, analysis)
+
+	// Convert error pattern map to slice
+	hints.ErrorChars = make([]rune, 0, len(m.errorPatterns))
+	for char := range m.errorPatterns {
+		hints.ErrorChars = append(hints.ErrorChars, char)
+	}
+	hints.ErrorWords = m.problemWords
+	hints.SlowNGrams = m.slowNGrams()
+	hints.Difficulty = sources.DifficultyLevels[m.difficultyLevel]
+	return hints
+}
+
 // generateNextSentenceCmd creates a command that generates the next sentence asynchronously
 func (m sessionModel) generateNextSentenceCmd() tea.Cmd {
+	hints := m.practiceHints()
 	return func() tea.Msg {
-		// Convert error pattern map to slice
-		errorChars := make([]rune, 0, len(m.errorPatterns))
-		for char := range m.errorPatterns {
-			errorChars = append(errorChars, char)
-		}
-
 		// Call LLM to generate next sentence
-		nextSentence, err := m.llmSource.GetNextSentence(m.lastSentence, errorChars, m.problemWords)
+		nextSentence, err := m.adaptiveSource.GetAdaptiveSentence(m.lastSentence, hints)
 		if err != nil {
 			return generationErrorMsg{err: err}
 		}
@@ -977,23 +1290,46 @@ func (m sessionModel) generateNextSentenceCmd() tea.Cmd {
 }
 
 func (m sessionModel) View() string {
-	if !m.hasStarted {
+	if !m.hasStarted && !m.drill {
 		var s strings.Builder
 
 		// Title
 		s.WriteString(DefaultTheme.Title.Render("Session Configuration"))
 		s.WriteString("\n\n")
 
-		// Duration selector in a box
-		durationText := fmt.Sprintf("%d minute", m.selectedDuration)
-		if m.selectedDuration != 1 {
-			durationText += "s"
-		}
-
 		var configContent strings.Builder
-		configContent.WriteString(DefaultTheme.Info.Render("Session Duration:") + "\n\n")
-		configContent.WriteString(DefaultTheme.Highlight.Render(fmt.Sprintf("       %s", durationText)) + "\n\n")
-		configContent.WriteString(DefaultTheme.Muted.Render("Use ↑/↓ arrows to adjust (1-60 minutes)"))
+		configContent.WriteString(DefaultTheme.Info.Render("Mode:") + "\n\n")
+		configContent.WriteString(DefaultTheme.Highlight.Render(fmt.Sprintf("◀ %s ▶", modeLabel(m.activeMode()))) + "\n\n")
+
+		// Mode-specific setting
+		switch m.activeMode() {
+		case types.ModeTime:
+			durationText := durationLabel(m.selectedDuration)
+			if m.customDuration != "" {
+				durationText = m.customDuration + "_"
+			}
+			configContent.WriteString(DefaultTheme.Info.Render("Session Duration:") + "\n\n")
+			configContent.WriteString(DefaultTheme.Highlight.Render(fmt.Sprintf("       %s", durationText)) + "\n\n")
+			if m.durationErr != "" {
+				configContent.WriteString(DefaultTheme.Incorrect.Render(m.durationErr) + "\n")
+			}
+			configContent.WriteString(DefaultTheme.Muted.Render("Use ↑/↓ arrows for presets (15s-60m)") + "\n")
+			configContent.WriteString(DefaultTheme.Muted.Render("or type a duration, e.g. 45s or 3m"))
+		case types.ModeWords:
+			configContent.WriteString(DefaultTheme.Info.Render("Word Count:") + "\n\n")
+			configContent.WriteString(DefaultTheme.Highlight.Render(fmt.Sprintf("%d words", m.targetWordCount())) + "\n\n")
+			configContent.WriteString(DefaultTheme.Muted.Render("Use ↑/↓ arrows to adjust"))
+		case types.ModeText:
+			configContent.WriteString(DefaultTheme.Muted.Render("Type the text to the end, no time limit"))
+		case types.ModeZen:
+			configContent.WriteString(DefaultTheme.Muted.Render("No time limit, press ESC when you are done"))
+		case types.ModeSuddenDeath:
+			configContent.WriteString(DefaultTheme.Muted.Render("The first error ends the run"))
+		}
+		configContent.WriteString("\n" + DefaultTheme.Muted.Render("Use ←/→ arrows to change mode"))
+		if label := m.strictnessLabel(); label != "" {
+			configContent.WriteString("\n\n" + DefaultTheme.Warning.Render(label))
+		}
 
 		configBox := lipgloss.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
@@ -1030,19 +1366,42 @@ func (m sessionModel) View() string {
 
 	terminalWidth := m.width
 
+	if m.paused {
+		return m.pauseOverlay()
+	}
+
 	// Calculate and display stats header
-	elapsed := time.Since(m.startTime)
-	remaining := m.sessionDuration - elapsed
+	elapsed := m.activeElapsed(time.Now())
 	currentWPM := m.getCurrentWPM()
 	currentAccuracy := m.getCurrentAccuracy()
 
-	// Display remaining time in minutes:seconds format
-	remainingMinutes := int(remaining.Minutes())
-	remainingSeconds := int(remaining.Seconds()) % 60
+	// The header leads with the mode's progress towards its end condition
+	var progressText string
+	progressPercent := -1.0
+	switch m.activeMode() {
+	case types.ModeTime:
+		// Display remaining time in minutes:seconds format
+		remaining := m.sessionDuration - elapsed
+		if remaining < 0 {
+			remaining = 0
+		}
+		progressText = fmt.Sprintf("Time Remaining: %s", formatDuration(remaining))
+		progressPercent = float64(elapsed) / float64(m.sessionDuration)
+	case types.ModeWords:
+		typed := m.wordsTyped()
+		progressText = fmt.Sprintf("Words: %d/%d", typed, m.targetWordCount())
+		progressPercent = float64(typed) / float64(m.targetWordCount())
+	case types.ModeText:
+		progressText = fmt.Sprintf("Elapsed: %s", formatDuration(elapsed))
+		if len(m.text) > 0 {
+			progressPercent = float64(len(m.typedText)) / float64(len(m.text))
+		}
+	default:
+		progressText = fmt.Sprintf("Elapsed: %s", formatDuration(elapsed))
+	}
 
-	statsHeader := fmt.Sprintf("Time Remaining: %d:%02d | WPM: %.0f | Accuracy: %.1f%% | Errors: %d",
-		remainingMinutes,
-		remainingSeconds,
+	statsHeader := fmt.Sprintf("%s | WPM: %.0f | Accuracy: %.1f%% | Errors: %d",
+		progressText,
 		currentWPM,
 		currentAccuracy,
 		m.errors,
@@ -1056,95 +1415,36 @@ func (m sessionModel) View() string {
 	statsHeader += "\n"
 	result.WriteString(statsHeader)
 
-	// Add progress bar based on time elapsed
-	progressPercent := float64(elapsed) / float64(m.sessionDuration)
-	if progressPercent > 1.0 {
-		progressPercent = 1.0
-	}
-	progressBarWidth := terminalWidth - 10 // Leave margin
-	if progressBarWidth > 50 {
-		progressBarWidth = 50 // Max width
-	}
-	filledWidth := int(float64(progressBarWidth) * progressPercent)
-	emptyWidth := progressBarWidth - filledWidth
-
-	progressBar := DefaultTheme.ProgressFill.Render(strings.Repeat("█", filledWidth)) +
-		DefaultTheme.ProgressBar.Render(strings.Repeat("░", emptyWidth))
-
-	result.WriteString(fmt.Sprintf("Progress: [%s] %.0f%%\n\n", progressBar, progressPercent*100))
-
-	// Reserve space for margins
-	displayWidth := terminalWidth - 4
-
-	// Current cursor position (next character to type)
-	cursorPos := len(m.typedText)
-
-	// Calculate center position
-	centerPos := displayWidth / 2
-
-	// Calculate viewport window
-	var viewportStart, viewportEnd int
-
-	textLen := len(m.text)
-
-	// If text is shorter than display width, no scrolling needed
-	if textLen <= displayWidth {
-		viewportStart = 0
-		viewportEnd = textLen
-	} else {
-		// Calculate viewport to keep cursor centered
-		viewportStart = cursorPos - centerPos
-		viewportEnd = viewportStart + displayWidth
-
-		// Adjust if we're at the beginning
-		if viewportStart < 0 {
-			viewportStart = 0
-			viewportEnd = displayWidth
+	// Add progress bar for modes with a fixed end point
+	if progressPercent >= 0 {
+		if progressPercent > 1.0 {
+			progressPercent = 1.0
 		}
-
-		// Clamp viewport end to text length without breaking center alignment
-		if viewportEnd > textLen {
-			viewportEnd = textLen
+		progressBarWidth := terminalWidth - 10 // Leave margin
+		if progressBarWidth > 50 {
+			progressBarWidth = 50 // Max width
 		}
-	}
-
-	// Check if flash effect is active
-	isFlashing := !m.typoFlashTime.IsZero() && time.Since(m.typoFlashTime) < time.Duration(m.config.Ui.TypoFlashDurationMs)*time.Millisecond
+		filledWidth := int(float64(progressBarWidth) * progressPercent)
+		emptyWidth := progressBarWidth - filledWidth
 
-	// Render visible characters
-	// Note: cursor position on screen = (cursorPos - viewportStart)
-	// The viewport calculation already handles centering
-	for i := viewportStart; i < viewportEnd; i++ {
-		char := m.text[i]
-		charStr := string(char)
+		progressBar := DefaultTheme.ProgressFill.Render(strings.Repeat("█", filledWidth)) +
+			DefaultTheme.ProgressBar.Render(strings.Repeat("░", emptyWidth))
 
-		// Skip newlines and tabs (as per user's notes)
-		if char == '\n' || char == '\t' {
-			continue
-		}
-
-		// Apply styling based on typing status
-		var styledChar string
+		result.WriteString(fmt.Sprintf("Progress: [%s] %.0f%%\n\n", progressBar, progressPercent*100))
+	} else {
+		result.WriteString("\n")
+	}
 
-		// If flash effect is active, render all text in red
-		if isFlashing {
-			styledChar = DefaultTheme.Incorrect.Render(charStr) // Red flash
-		} else if i < len(m.typedText) {
-			// Character has been typed
-			if m.typedText[i] == m.text[i] {
-				styledChar = DefaultTheme.Correct.Render(charStr) // Green
-			} else {
-				styledChar = DefaultTheme.Incorrect.Render(charStr) // Red
-			}
-		} else if i == len(m.typedText) {
-			// Current cursor position
-			styledChar = DefaultTheme.Current.Render(charStr) // Yellow/highlighted
-		} else {
-			// Not yet typed
-			styledChar = DefaultTheme.Normal.Render(charStr) // Default
-		}
+	// Render the text in the configured layout
+	if m.config.Ui.Layout == layoutParagraph {
+		result.WriteString(m.renderParagraph(terminalWidth))
+	} else {
+		result.WriteString(m.renderLine(terminalWidth))
+	}
 
-		result.WriteString(styledChar)
+	if m.keyboardVisible() {
+		result.WriteString("\n\n")
+		result.WriteString(m.renderKeyboard())
 	}
 
 	// Display mistyped words in boxes
@@ -1157,7 +1457,7 @@ func (m sessionModel) View() string {
 		boxStyle := lipgloss.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
 			BorderForeground(lipgloss.Color("8")). // Grey border
-			Padding(0, 0)                           // Minimal padding for 75% smaller boxes
+			Padding(0, 0)                          // Minimal padding for 75% smaller boxes
 
 		// Collect all boxes
 		var boxes []string
@@ -1172,14 +1472,19 @@ func (m sessionModel) View() string {
 		result.WriteString("\n")
 	}
 
-	result.WriteString("\n\nPress ESC or CTRL-C to exit")
+	if m.activeMode() == types.ModeZen {
+		result.WriteString("\n\nPress CTRL-P to pause • CTRL-K for keyboard • ESC to finish or CTRL-C to cancel")
+	} else {
+		result.WriteString("\n\nPress CTRL-P to pause • CTRL-K for keyboard • ESC or CTRL-C to exit")
+	}
 
 	return result.String()
 }
 
-func startSession(config types.Config, text string, textSource sources.TextSource) (types.TypingSession, error) {
-	// Check if we're using LLM source
-	llmSource, isLLM := textSource.(*sources.LLMSource)
+// newSessionModel creates a session on the configuration screen
+func newSessionModel(config types.Config, text string, textSource sources.TextSource, layout *keyboard.Layout, ngrams types.NGramStats) sessionModel {
+	// Check if the source can generate follow-up text
+	adaptiveSource, isAdaptive := textSource.(sources.AdaptiveSource)
 
 	// Create initial model
 	model := sessionModel{
@@ -1194,16 +1499,24 @@ func startSession(config types.Config, text string, textSource sources.TextSourc
 		width:            0, // Will be set by WindowSizeMsg
 		height:           0, // Will be set by WindowSizeMsg
 		viewportOffset:   0, // Start at beginning
-		selectedDuration: 1, // Default to 1 minute
+		selectedDuration: defaultDuration(config),
+		mode:             types.ModeTime,
+		idleLimit:        idleThreshold(config),
+		keyboard:         layout,
+		showKeyboard:     config.Ui.ShowKeyboard,
+		wordCount:        wordCountOptions[1],
 		sessionDuration:  0, // Will be set when session starts
 
 		// LLM fields
-		isLLMSource:           isLLM,
-		llmSource:             llmSource,
+		source:                sources.SourceName(textSource),
+		isAdaptiveSource:      isAdaptive,
+		adaptiveSource:        adaptiveSource,
+		ngramHistory:          ngrams,
+		difficultyLevel:       sources.DefaultDifficultyLevel,
 		lastSentence:          text, // First sentence becomes context
 		errorPatterns:         make(map[rune]int),
 		problemWords:          make([]string, 0),
-		currentProblemWords:   make([]string, 0), // Initialize mistyped words display
+		currentProblemWords:   make([]string, 0),  // Initialize mistyped words display
 		wordsWithErrors:       make(map[int]bool), // Track positions with errors
 		lastWordEnd:           0,                  // Track word completion
 		generationPending:     false,
@@ -1221,7 +1534,12 @@ func startSession(config types.Config, text string, textSource sources.TextSourc
 		currentWordIndex: 0,
 		targetWords:      strings.Fields(text), // Split target text into words
 	}
+	return model
+}
 
+// runSession runs the session program and builds the session record from the
+// final model, folding its n-gram timings into ngrams and its reviews into the deck file
+func runSession(model sessionModel, ngrams *types.NGramStats) (types.TypingSession, error) {
 	// Run the Bubbletea program with alternate screen
 	program := tea.NewProgram(model, tea.WithAltScreen())
 	finalModel, err := program.Run()
@@ -1237,37 +1555,55 @@ func startSession(config types.Config, text string, textSource sources.TextSourc
 		return types.TypingSession{}, errors.New("session cancelled by user")
 	}
 
-	// Calculate duration
-	duration := session.lastKeyTime.Sub(session.startTime)
+	// Calculate duration, excluding time spent paused
+	duration := session.activeElapsed(session.lastKeyTime)
 
-	// Calculate WPM (Words Per Minute)
+	// Calculate WPM, accuracy and consistency from what was actually typed
 	// Standard: 1 word = 5 characters
-	words := float32(len(session.text)) / 5.0
-	minutes := duration.Minutes()
-	wpm := words / float32(minutes)
-
-	// Calculate accuracy
-	totalChars := len(session.typedText)
-	correctChars := totalChars - session.errors
-	accuracy := float32(0)
-	if totalChars > 0 {
-		accuracy = (float32(correctChars) / float32(totalChars)) * 100
-	}
+	result := metrics.Compute(session.keystrokes, session.typedText, session.text, duration)
 
 	// Create the typing session result
-	result := types.TypingSession{
-		Date:     session.startTime,
-		WPM:      wpm,
-		Accuracy: accuracy,
-		Errors:   session.errors,
-		Duration: duration,
+	typingSession := types.TypingSession{
+		Date:              session.startTime,
+		WPM:               result.NetWPM,
+		Accuracy:          result.Accuracy,
+		Errors:            session.errors,
+		Duration:          duration,
+		Mode:              session.activeMode(),
+		GrossWPM:          result.GrossWPM,
+		NetWPM:            result.NetWPM,
+		RawWPM:            result.RawWPM,
+		CorrectedErrors:   result.CorrectedErrors,
+		UncorrectedErrors: result.UncorrectedErrors,
+		Consistency:       result.Consistency,
+		Pauses:            session.pauses,
+		PausedTime:        pausedTime(session.pauses),
+		KeyStats:          metrics.KeyStats(session.keystrokes),
+		Confusions:        metrics.Confusions(session.keystrokes),
+	}
+	if typingSession.Mode == types.ModeWords {
+		typingSession.WordCount = session.targetWordCount()
+	}
+	if err := session.recordNGrams(&typingSession, ngrams); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to save n-gram stats: %v\n", err)
+	}
+	typingSession.MistypedWords = mistypedWords(session.text, session.keystrokes)
+	typingSession.Drill = session.drill
+	session.tagSession(&typingSession)
+	if session.deckPath != "" {
+		err := srs.Update(session.deckPath, func(deck *srs.Deck) {
+			session.updateDeck(deck, typingSession, time.Now())
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to save review deck: %v\n", err)
+		}
 	}
 
-	return result, nil
+	return typingSession, nil
 }
 
 func Run(config types.Config, text string, textSource sources.TextSource) SessionResult {
-	stats, err := getUserStats(config)
+	store, history, warnings, err := stats.OpenHistory(config.Stats.FileDir)
 	if err != nil {
 		return SessionResult{
 			Error:   err,
@@ -1275,8 +1611,23 @@ func Run(config types.Config, text string, textSource sources.TextSource) Sessio
 			Exited:  false,
 		}
 	}
-
-	action, err := showWelcome(config, stats)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	notice := strings.Join(warnings, "\n")
+
+	action, err := showWelcome(config, history, notice)
+	// The history screen returns to the menu unless the user quits from it
+	for err == nil && action == History {
+		var quit bool
+		quit, err = showHistory(config, history)
+		switch {
+		case err == nil && quit:
+			action = Exit
+		case err == nil:
+			action, err = showWelcome(config, history, "")
+		}
+	}
 	if err != nil {
 		return SessionResult{
 			Error:   err,
@@ -1287,26 +1638,68 @@ func Run(config types.Config, text string, textSource sources.TextSource) Sessio
 
 	switch action {
 	case StartSession:
-		session, err := startSession(config, text, textSource)
+		layout := loadKeyboardLayout(config)
+		ngrams, err := getNGramStats(config)
 		if err != nil {
-			return SessionResult{Error: err, Session: nil, Exited: false}
+			// Start over rather than refuse to run; the file is rewritten after the session
+			fmt.Fprintf(os.Stderr, "Warning: Failed to load n-gram stats: %v\n", err)
+		}
+		deck, err := srs.Load(deckPath(config))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to load review deck: %v\n", err)
 		}
 
-		// Add session to stats
-		stats.Sessions = append(stats.Sessions, session)
+		// Weave the words due for review into the text, whatever the source
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+		dueWords, dueChars := dueReviews(&deck, time.Now(), rng)
+		if _, adaptive := textSource.(sources.AdaptiveSource); !adaptive {
+			// Fixed text cannot aim at slow transitions, so words with them are woven in
+			dueWords = append(dueWords, slowNGramWords(ngrams, rng)...)
+		}
+		model := newSessionModel(config, weaveWords(text, dueWords, rng), textSource, layout, ngrams)
+		model.deckPath = deckPath(config)
+		model.ngramPath = ngramStatsPath(config)
+		model.paceWPM = paceWPM(config, history)
+		model.seedReviews(dueWords, dueChars)
 
-		// Save updated stats
-		err = saveUserStats(config, stats)
+		session, err := runSession(model, &ngrams)
 		if err != nil {
-			// Log error but don't fail - we still show the results
-			fmt.Fprintf(os.Stderr, "Warning: Failed to save stats: %v\n", err)
+			return SessionResult{Error: err, Session: nil, Exited: false}
 		}
 
-		// Show dashboard with results
-		err = showDashboard(config, session, stats)
-		if err != nil {
-			// Dashboard error shouldn't fail the whole thing
-			fmt.Fprintf(os.Stderr, "Warning: Failed to show dashboard: %v\n", err)
+		for {
+			// Add session to stats
+			history.Sessions = append(history.Sessions, session)
+
+			// Record the session
+			err = store.Append(session)
+			if err != nil {
+				// Log error but don't fail - we still show the results
+				fmt.Fprintf(os.Stderr, "Warning: Failed to save stats: %v\n", err)
+			}
+
+			// Show dashboard with results
+			drill, err := showDashboard(config, session, history, layout, ngrams)
+			if err != nil {
+				// Dashboard error shouldn't fail the whole thing
+				fmt.Fprintf(os.Stderr, "Warning: Failed to show dashboard: %v\n", err)
+			}
+			if !drill {
+				break
+			}
+
+			// Drill the mistyped words, then show the drill's own dashboard
+			model := newSessionModel(config, drillText(session.MistypedWords, rng), nil, layout, ngrams)
+			model.mode = types.ModeText
+			model.drill = true
+			model.deckPath = deckPath(config)
+			model.ngramPath = ngramStatsPath(config)
+			drillSession, err := runSession(model, &ngrams)
+			if err != nil {
+				// A cancelled drill leaves the original session as the result
+				break
+			}
+			session = drillSession
 		}
 
 		return SessionResult{Error: nil, Session: &session, Exited: false}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"go-touch/internal/types"
 	"os"
-	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -307,158 +306,6 @@ func TestGetCurrentAccuracy(t *testing.T) {
 	}
 }
 
-func TestGetUserStats_FileNotExists(t *testing.T) {
-	tmpDir := t.TempDir()
-	config := types.Config{
-		Stats: types.StatsConfig{
-			FileDir: filepath.Join(tmpDir, "nonexistent.json"),
-		},
-	}
-
-	stats, err := getUserStats(config)
-
-	// Should not return error for non-existent file
-	if err != nil {
-		t.Errorf("getUserStats() unexpected error: %v", err)
-	}
-
-	// For non-existent file, Sessions will be nil (zero value)
-	// This is acceptable behavior - can be empty or nil
-	if stats.Sessions != nil && len(stats.Sessions) != 0 {
-		t.Errorf("getUserStats() Sessions length = %d, want 0 or nil", len(stats.Sessions))
-	}
-}
-
-func TestGetUserStats_EmptyFile(t *testing.T) {
-	tmpDir := t.TempDir()
-	statsFile := filepath.Join(tmpDir, "stats.json")
-
-	// Create empty file
-	err := os.WriteFile(statsFile, []byte(""), 0644)
-	if err != nil {
-		t.Fatalf("Failed to create test file: %v", err)
-	}
-
-	config := types.Config{
-		Stats: types.StatsConfig{
-			FileDir: statsFile,
-		},
-	}
-
-	stats, err := getUserStats(config)
-
-	if err != nil {
-		t.Errorf("getUserStats() unexpected error for empty file: %v", err)
-	}
-
-	if stats.Sessions == nil {
-		t.Errorf("getUserStats() Sessions should be initialized, got nil")
-	}
-}
-
-func TestGetUserStats_ValidFile(t *testing.T) {
-	tmpDir := t.TempDir()
-	statsFile := filepath.Join(tmpDir, "stats.json")
-
-	// Create valid stats file
-	validJSON := `{
-		"sessions": [
-			{
-				"date": "2024-01-01T12:00:00Z",
-				"wpm": 50,
-				"accuracy": 95,
-				"errors": 5,
-				"duration": 60000000000
-			}
-		]
-	}`
-
-	err := os.WriteFile(statsFile, []byte(validJSON), 0644)
-	if err != nil {
-		t.Fatalf("Failed to create test file: %v", err)
-	}
-
-	config := types.Config{
-		Stats: types.StatsConfig{
-			FileDir: statsFile,
-		},
-	}
-
-	stats, err := getUserStats(config)
-
-	if err != nil {
-		t.Errorf("getUserStats() unexpected error: %v", err)
-	}
-
-	if len(stats.Sessions) != 1 {
-		t.Errorf("getUserStats() Sessions length = %d, want 1", len(stats.Sessions))
-	}
-
-	if stats.Sessions[0].WPM != 50 {
-		t.Errorf("getUserStats() Session WPM = %v, want 50", stats.Sessions[0].WPM)
-	}
-
-	if stats.Sessions[0].Accuracy != 95 {
-		t.Errorf("getUserStats() Session Accuracy = %v, want 95", stats.Sessions[0].Accuracy)
-	}
-}
-
-func TestSaveUserStats(t *testing.T) {
-	tmpDir := t.TempDir()
-	statsFile := filepath.Join(tmpDir, "stats.json")
-
-	config := types.Config{
-		Stats: types.StatsConfig{
-			FileDir: statsFile,
-		},
-	}
-
-	stats := types.UserStats{
-		Sessions: []types.TypingSession{
-			{
-				Date:     time.Now(),
-				WPM:      45,
-				Accuracy: 92.5,
-				Errors:   8,
-				Duration: 90 * time.Second,
-			},
-		},
-	}
-
-	err := saveUserStats(config, stats)
-
-	if err != nil {
-		t.Errorf("saveUserStats() unexpected error: %v", err)
-	}
-
-	// Verify file was created
-	if _, err := os.Stat(statsFile); os.IsNotExist(err) {
-		t.Errorf("saveUserStats() did not create file")
-	}
-
-	// Read back and verify
-	loadedStats, err := getUserStats(config)
-	if err != nil {
-		t.Errorf("getUserStats() after save unexpected error: %v", err)
-	}
-
-	if len(loadedStats.Sessions) != 1 {
-		t.Fatalf("Loaded stats has %d sessions, want 1", len(loadedStats.Sessions))
-	}
-
-	if loadedStats.Sessions[0].WPM != 45 {
-		t.Errorf("Loaded WPM = %v, want 45", loadedStats.Sessions[0].WPM)
-	}
-
-	if loadedStats.Sessions[0].Accuracy != 92.5 {
-		t.Errorf("Loaded Accuracy = %v, want 92.5", loadedStats.Sessions[0].Accuracy)
-	}
-
-	if loadedStats.Sessions[0].Errors != 8 {
-		t.Errorf("Loaded Errors = %v, want 8", loadedStats.Sessions[0].Errors)
-	}
-}
-
 func TestSessionResult_String(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -531,6 +378,11 @@ func TestWelcomeAction_String(t *testing.T) {
 			action:   Exit,
 			expected: "Naah not today lets exit",
 		},
+		{
+			name:     "history",
+			action:   History,
+			expected: "Show me how I'm doing",
+		},
 	}
 
 	for _, tt := range tests {
@@ -557,16 +409,20 @@ func TestNewWelcomeModel(t *testing.T) {
 		t.Errorf("newWelcomeModel() cursor = %d, want 0", model.cursor)
 	}
 
-	if len(model.choices) != 2 {
-		t.Errorf("newWelcomeModel() choices length = %d, want 2", len(model.choices))
+	if len(model.choices) != 3 {
+		t.Errorf("newWelcomeModel() choices length = %d, want 3", len(model.choices))
 	}
 
 	if model.choices[0] != StartSession {
 		t.Errorf("newWelcomeModel() choices[0] = %v, want StartSession", model.choices[0])
 	}
 
-	if model.choices[1] != Exit {
-		t.Errorf("newWelcomeModel() choices[1] = %v, want Exit", model.choices[1])
+	if model.choices[1] != History {
+		t.Errorf("newWelcomeModel() choices[1] = %v, want History", model.choices[1])
+	}
+
+	if model.choices[2] != Exit {
+		t.Errorf("newWelcomeModel() choices[2] = %v, want Exit", model.choices[2])
 	}
 
 	if model.done {
@@ -715,7 +571,7 @@ func TestSessionModel_View_NotStarted(t *testing.T) {
 		text:             "test text",
 		typedText:        "",
 		hasStarted:       false,
-		selectedDuration: 5,
+		selectedDuration: 5 * time.Minute,
 	}
 
 	view := model.View()
@@ -787,7 +643,7 @@ func TestSessionModel_View_WithGenerationPending(t *testing.T) {
 		sessionDuration:   1 * time.Minute,
 		lastKeyTime:       time.Now(),
 		generationPending: true,
-		isLLMSource:       true,
+		isAdaptiveSource:  true,
 	}
 
 	view := model.View()
@@ -1127,16 +983,16 @@ func TestSessionModel_Update_UpDownKeys(t *testing.T) {
 	model := sessionModel{
 		text:             "test text",
 		hasStarted:       false,
-		selectedDuration: 5,
+		selectedDuration: 5 * time.Minute,
 	}
 
-	// Test up key
+	// Test up key moves to the next preset
 	msgUp := tea.KeyMsg{Type: tea.KeyUp}
 	updatedModel, _ := model.Update(msgUp)
 	m := updatedModel.(sessionModel)
 
-	if m.selectedDuration != 6 {
-		t.Errorf("After up key, selectedDuration = %d, want 6", m.selectedDuration)
+	if m.selectedDuration != 10*time.Minute {
+		t.Errorf("After up key, selectedDuration = %v, want 10m", m.selectedDuration)
 	}
 
 	// Test down key
@@ -1144,8 +1000,8 @@ func TestSessionModel_Update_UpDownKeys(t *testing.T) {
 	updatedModel2, _ := m.Update(msgDown)
 	m2 := updatedModel2.(sessionModel)
 
-	if m2.selectedDuration != 5 {
-		t.Errorf("After down key, selectedDuration = %d, want 5", m2.selectedDuration)
+	if m2.selectedDuration != 5*time.Minute {
+		t.Errorf("After down key, selectedDuration = %v, want 5m", m2.selectedDuration)
 	}
 }
 
@@ -1154,10 +1010,10 @@ func TestGenerateNextSentenceCmd(t *testing.T) {
 	// Create a simple test - we can't actually test the async behavior easily
 	// but we can test that the function returns a command
 	model := sessionModel{
-		isLLMSource:   true,
-		errorPatterns: map[rune]int{'a': 1},
-		problemWords:  []string{"test"},
-		lastSentence:  "Hello world",
+		isAdaptiveSource: true,
+		errorPatterns:    map[rune]int{'a': 1},
+		problemWords:     []string{"test"},
+		lastSentence:     "Hello world",
 	}
 
 	cmd := model.generateNextSentenceCmd()
@@ -1179,7 +1035,7 @@ func TestSessionModel_Update_EnterToStart(t *testing.T) {
 	model := sessionModel{
 		text:             "test text",
 		hasStarted:       false,
-		selectedDuration: 3,
+		selectedDuration: 3 * time.Minute,
 	}
 
 	msg := tea.KeyMsg{Type: tea.KeyEnter}
@@ -1302,7 +1158,7 @@ func TestSessionModel_Update_SessionComplete(t *testing.T) {
 		lastKeyTime:           time.Now(),
 		keyStrokeTimes:        []time.Duration{},
 		currentSentenceEndPos: 4,
-		isLLMSource:           false,
+		isAdaptiveSource:      false,
 		wordsWithErrors:       map[int]bool{},
 		currentProblemWords:   []string{},
 		lastWordEnd:           0,
@@ -1327,7 +1183,7 @@ func TestSessionModel_Update_GenerationComplete(t *testing.T) {
 	model := sessionModel{
 		text:              "first sentence",
 		typedText:         "",
-		isLLMSource:       true,
+		isAdaptiveSource:  true,
 		generationPending: true,
 		nextSentenceReady: false,
 	}
@@ -1380,7 +1236,7 @@ func TestSessionModel_Update_PregenTrigger(t *testing.T) {
 	model := sessionModel{
 		text:                  "this is a test sentence",
 		typedText:             "this is a te",
-		isLLMSource:           true,
+		isAdaptiveSource:      true,
 		hasStarted:            true,
 		startTime:             time.Now(),
 		sessionDuration:       5 * time.Minute,
@@ -1413,30 +1269,30 @@ func TestSessionModel_Update_UpDownMaxMin(t *testing.T) {
 	model := sessionModel{
 		text:             "test",
 		hasStarted:       false,
-		selectedDuration: 60,
+		selectedDuration: 60 * time.Minute,
 	}
 
 	msg := tea.KeyMsg{Type: tea.KeyUp}
 	updatedModel, _ := model.Update(msg)
 	m := updatedModel.(sessionModel)
 
-	if m.selectedDuration != 60 {
-		t.Errorf("Should cap at 60 minutes, got %d", m.selectedDuration)
+	if m.selectedDuration != 60*time.Minute {
+		t.Errorf("Should cap at 60 minutes, got %v", m.selectedDuration)
 	}
 
 	// Test min limit
 	model2 := sessionModel{
 		text:             "test",
 		hasStarted:       false,
-		selectedDuration: 1,
+		selectedDuration: 15 * time.Second,
 	}
 
 	msg2 := tea.KeyMsg{Type: tea.KeyDown}
 	updatedModel2, _ := model2.Update(msg2)
 	m2 := updatedModel2.(sessionModel)
 
-	if m2.selectedDuration != 1 {
-		t.Errorf("Should floor at 1 minute, got %d", m2.selectedDuration)
+	if m2.selectedDuration != 15*time.Second {
+		t.Errorf("Should floor at 15 seconds, got %v", m2.selectedDuration)
 	}
 }
 
@@ -1463,7 +1319,7 @@ func TestSessionModel_View_SessionConfig(t *testing.T) {
 	model := sessionModel{
 		text:             "test text",
 		hasStarted:       false,
-		selectedDuration: 10,
+		selectedDuration: 10 * time.Minute,
 		width:            80,
 	}
 
@@ -1483,7 +1339,7 @@ func TestSessionModel_View_SingleMinute(t *testing.T) {
 	model := sessionModel{
 		text:             "test",
 		hasStarted:       false,
-		selectedDuration: 1,
+		selectedDuration: time.Minute,
 		width:            80,
 	}
 
@@ -1515,7 +1371,7 @@ func TestSessionModel_Update_LLMSentenceTransition(t *testing.T) {
 	model := sessionModel{
 		text:                  "first sentence second sentence",
 		typedText:             "first sentenc",
-		isLLMSource:           true,
+		isAdaptiveSource:      true,
 		hasStarted:            true,
 		startTime:             time.Now(),
 		lastKeyTime:           time.Now(),
@@ -1550,7 +1406,7 @@ func TestSessionModel_Update_LLMWaitingForGeneration(t *testing.T) {
 	model := sessionModel{
 		text:                  "first",
 		typedText:             "first",
-		isLLMSource:           true,
+		isAdaptiveSource:      true,
 		hasStarted:            true,
 		startTime:             time.Now(),
 		lastKeyTime:           time.Now(),
@@ -1579,7 +1435,7 @@ func TestSessionModel_Update_LLMCompleteAllText(t *testing.T) {
 	model := sessionModel{
 		text:                  "test",
 		typedText:             "tes",
-		isLLMSource:           true,
+		isAdaptiveSource:      true,
 		hasStarted:            true,
 		startTime:             time.Now(),
 		lastKeyTime:           time.Now(),
@@ -1800,3 +1656,45 @@ func TestTypoFlashMsg(t *testing.T) {
 		t.Error("typoFlashTime should be cleared after flash message")
 	}
 }
+
+// TestSessionModel_KeystrokeLog tests that typed characters and backspaces are recorded
+func TestSessionModel_KeystrokeLog(t *testing.T) {
+	model := sessionModel{
+		text:                  "test",
+		hasStarted:            true,
+		startTime:             time.Now(),
+		lastKeyTime:           time.Now(),
+		wordsWithErrors:       map[int]bool{},
+		currentProblemWords:   []string{},
+		targetWords:           []string{"test"},
+		currentSentenceEndPos: 4,
+	}
+
+	var updated tea.Model = model
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m := updated.(sessionModel)
+
+	if len(m.keystrokes) != 3 {
+		t.Fatalf("keystrokes length = %d, want 3", len(m.keystrokes))
+	}
+	if !m.keystrokes[0].Correct() {
+		t.Error("first keystroke should be correct")
+	}
+	if m.keystrokes[1].Expected != 'e' || m.keystrokes[1].Typed != 'x' {
+		t.Errorf("second keystroke = %+v, want expected 'e' typed 'x'", m.keystrokes[1])
+	}
+	if !m.keystrokes[2].Backspace {
+		t.Error("third keystroke should be a backspace")
+	}
+}
+
+func TestWelcomeModel_ViewNotice(t *testing.T) {
+	model := newWelcomeModel(types.Config{}, types.UserStats{})
+	model.notice = "stats were damaged"
+
+	if view := model.View(); !contains(view, "stats were damaged") {
+		t.Errorf("View() should show the notice")
+	}
+}
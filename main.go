@@ -1,13 +1,16 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"go-touch/internal/config"
 	"go-touch/internal/sources"
 	"go-touch/internal/types"
 	"go-touch/internal/ui"
+	"io"
 	"log"
+	"os"
 )
 
 func getText(cfg types.Config) (string, sources.TextSource, error) {
@@ -19,7 +22,24 @@ func getText(cfg types.Config) (string, sources.TextSource, error) {
 	return text, textSource, err
 }
 
+// commands are the subcommands that work on the history without starting a session
+var commands = map[string]func(args []string, output io.Writer) error{
+	"stats":  runStats,
+	"export": runExport,
+	"import": runImport,
+}
+
 func main() {
+	// Subcommands run without the TUI
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(os.Args[2:], os.Stdout); err != nil && !errors.Is(err, flag.ErrHelp) {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+
 	// Parse command-line flags
 	configPath := flag.String("config", "", "Path to config file")
 	flag.Parse()
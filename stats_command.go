@@ -0,0 +1,301 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go-touch/internal/analytics"
+	"go-touch/internal/config"
+	"go-touch/internal/stats"
+	"go-touch/internal/types"
+	"go-touch/internal/ui"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// statsOptions holds the flags of the stats command
+type statsOptions struct {
+	configPath string
+	filter     stats.Filter
+	by         stats.Period
+	bests      bool
+	insights   bool
+	target     float64 // Target WPM for --insights, the configured goal when zero
+	json       bool
+}
+
+// parseStatsFlags reads the arguments following "gotouch stats"
+func parseStatsFlags(args []string, now time.Time, output io.Writer) (statsOptions, error) {
+	var opts statsOptions
+	flags := flag.NewFlagSet("gotouch stats", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.StringVar(&opts.configPath, "config", "", "Path to config file")
+	since := flags.String("since", "", "Only sessions since a date (2006-01-02) or for a recent span (24h, 7d, 4w)")
+	mode := flags.String("mode", "", "Only sessions of a mode: time, words, text, zen or sudden_death")
+	flags.StringVar(&opts.filter.Source, "source", "", "Only sessions from a text source: dummy, llm, practice or drill")
+	flags.BoolVar(&opts.filter.SkipIdle, "no-idle", false, "Leave out sessions that were paused because you went idle")
+	by := flags.String("by", "", "Group sessions by day, week or month")
+	flags.BoolVar(&opts.bests, "bests", false, "Show the personal best of each mode, duration or word count and source")
+	flags.BoolVar(&opts.insights, "insights", false, "Show learning curve insights: trend, plateaus, target projection and unusual sessions")
+	flags.Float64Var(&opts.target, "target", 0, "Target WPM to project for --insights (default goals.target_wpm)")
+	flags.BoolVar(&opts.json, "json", false, "Print JSON instead of a table")
+
+	if err := flags.Parse(args); err != nil {
+		return opts, err
+	}
+	if flags.NArg() > 0 {
+		return opts, fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+
+	var err error
+	if *since != "" {
+		if opts.filter.Since, err = parseSince(*since, now); err != nil {
+			return opts, err
+		}
+	}
+	if *mode != "" {
+		if opts.filter.Mode, err = types.ParseTestMode(*mode); err != nil {
+			return opts, err
+		}
+	}
+	if *by != "" && opts.bests {
+		return opts, errors.New("--by and --bests cannot be combined")
+	}
+	if opts.insights && (*by != "" || opts.bests) {
+		return opts, errors.New("--insights cannot be combined with --by or --bests")
+	}
+	if *by != "" {
+		if opts.by, err = stats.ParsePeriod(*by); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// parseSince reads a --since value: a date, or a span back from now in hours,
+// days or weeks
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if date, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return date, nil
+	}
+
+	unit := value[len(value)-1:]
+	count, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || count < 0 {
+		return time.Time{}, fmt.Errorf("invalid --since %q, want a date like 2006-01-02 or a span like 7d", value)
+	}
+	switch unit {
+	case "h":
+		return now.Add(-time.Duration(count) * time.Hour), nil
+	case "d":
+		return now.AddDate(0, 0, -count), nil
+	case "w":
+		return now.AddDate(0, 0, -7*count), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q, want a date like 2006-01-02 or a span like 7d", value)
+}
+
+// runStats prints the filtered session history without starting the TUI
+func runStats(args []string, output io.Writer) error {
+	opts, err := parseStatsFlags(args, time.Now(), output)
+	if err != nil {
+		return err
+	}
+
+	cfg, history, err := loadHistory(opts.configPath)
+	if err != nil {
+		return err
+	}
+	if opts.target == 0 {
+		opts.target = float64(cfg.Goals.TargetWPM)
+	}
+	return printStats(output, opts, opts.filter.Apply(history).Sessions)
+}
+
+// loadHistory reads the history configured in the config file without
+// changing anything on disk
+func loadHistory(configPath string) (*types.Config, types.UserStats, error) {
+	cfg, _, err := config.LoadOrCreateConfig(configPath)
+	if err != nil {
+		return nil, types.UserStats{}, fmt.Errorf("failed to load config: %w", err)
+	}
+	history, warnings, err := stats.ReadHistory(cfg.Stats.FileDir)
+	if err != nil {
+		return cfg, history, fmt.Errorf("failed to load stats: %w", err)
+	}
+	printWarnings(warnings)
+	return cfg, history, nil
+}
+
+// openHistory opens the stats store configured in the config file for
+// commands that change the history
+func openHistory(configPath string) (stats.StatsStore, error) {
+	cfg, _, err := config.LoadOrCreateConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	store, _, warnings, err := stats.OpenHistory(cfg.Stats.FileDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stats: %w", err)
+	}
+	printWarnings(warnings)
+	return store, nil
+}
+
+// printWarnings reports problems with the history on stderr, keeping them out
+// of the command's output
+func printWarnings(warnings []string) {
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+}
+
+// printStats writes the sessions, or their summaries per period, as a table or JSON
+func printStats(output io.Writer, opts statsOptions, sessions []types.TypingSession) error {
+	if opts.json {
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+		if opts.insights {
+			return encoder.Encode(insightsByClass(sessions, opts.target, time.Now()))
+		}
+		if opts.bests {
+			return encoder.Encode(stats.PersonalBests(types.UserStats{Sessions: sessions}))
+		}
+		if opts.by != "" {
+			return encoder.Encode(stats.Aggregate(sessions, opts.by))
+		}
+		return encoder.Encode(sessions)
+	}
+
+	if len(sessions) == 0 {
+		_, err := fmt.Fprintln(output, "No sessions found")
+		return err
+	}
+
+	if opts.insights {
+		for i, class := range insightsByClass(sessions, opts.target, time.Now()) {
+			if i > 0 {
+				fmt.Fprintln(output)
+			}
+			if err := printInsights(output, class); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	table := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	if opts.bests {
+		fmt.Fprintln(table, "MODE\tSOURCE\tBEST WPM\tACCURACY\tDATE\tSESSIONS")
+		for _, best := range stats.PersonalBests(types.UserStats{Sessions: sessions}) {
+			source := best.Key.Source
+			if source == "" {
+				source = "-"
+			}
+			fmt.Fprintf(table, "%s\t%s\t%.1f\t%.1f%%\t%s\t%d\n",
+				ui.SessionLabel(best.Session), source, best.Session.WPM, best.Session.Accuracy,
+				best.Session.Date.Local().Format("2006-01-02 15:04"), best.Sessions)
+		}
+	} else if opts.by != "" {
+		fmt.Fprintln(table, strings.ToUpper(string(opts.by))+"\tSESSIONS\tAVG WPM\tBEST WPM\tACCURACY\tTIME")
+		for _, summary := range stats.Aggregate(sessions, opts.by) {
+			fmt.Fprintf(table, "%s\t%d\t%.1f\t%.1f\t%.1f%%\t%s\n",
+				periodLabel(summary.Start, opts.by), summary.Sessions, summary.AvgWPM, summary.BestWPM,
+				summary.AvgAccuracy, summary.Duration.Round(time.Second))
+		}
+	} else {
+		fmt.Fprintln(table, "DATE\tMODE\tSOURCE\tWPM\tACCURACY\tERRORS\tTIME")
+		for _, session := range sessions {
+			source := session.Source
+			if source == "" {
+				source = "-"
+			}
+			fmt.Fprintf(table, "%s\t%s\t%s\t%.1f\t%.1f%%\t%d\t%s\n",
+				session.Date.Local().Format("2006-01-02 15:04"), ui.SessionLabel(session), source,
+				session.WPM, session.Accuracy, session.Errors, session.Duration.Round(time.Second))
+		}
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	total := stats.Summarize(sessions)
+	_, err := fmt.Fprintf(output, "\n%d sessions • Avg WPM: %.1f • Best WPM: %.1f • Avg Accuracy: %.1f%%\n",
+		total.Sessions, total.AvgWPM, total.BestWPM, total.AvgAccuracy)
+	return err
+}
+
+// classInsights is the learning curve of one personal best class
+type classInsights struct {
+	Key      stats.BestKey      `json:"key"`
+	Label    string             `json:"label"`
+	Insights analytics.Insights `json:"insights"`
+}
+
+// insightsByClass analyzes each personal best class on its own, so 15-second
+// sprints, 10-minute runs and zen sessions do not share one learning curve.
+// Drills are left out like in Analyze.
+func insightsByClass(sessions []types.TypingSession, target float64, now time.Time) []classInsights {
+	history := types.UserStats{Sessions: sessions}
+	classes := make([]classInsights, 0)
+	for _, best := range stats.PersonalBests(history) {
+		if best.Key.Drill {
+			continue
+		}
+		label := ui.SessionLabel(best.Session)
+		if best.Key.Source != "" {
+			label += " • " + best.Key.Source
+		}
+		classes = append(classes, classInsights{
+			Key:      best.Key,
+			Label:    label,
+			Insights: analytics.Analyze(stats.Comparable(history, best.Session).Sessions, target, now),
+		})
+	}
+	return classes
+}
+
+// printInsights writes the insights of a class as lines of feedback followed
+// by the fitted curve and the unusual sessions
+func printInsights(output io.Writer, class classInsights) error {
+	insights := class.Insights
+	fmt.Fprintln(output, class.Label)
+	for _, message := range insights.Messages() {
+		fmt.Fprintln(output, "  "+message)
+	}
+	if !insights.Ready {
+		return nil
+	}
+
+	fmt.Fprintf(output, "  Learning curve over %d sessions: %.1f + %.1f × ln(session) WPM, fit %.0f%%\n",
+		insights.Sessions, insights.Curve.Intercept, insights.Curve.Slope, insights.Curve.R2*100)
+	if len(insights.Anomalies) == 0 {
+		return nil
+	}
+	fmt.Fprintln(output)
+	table := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "  DATE\tWPM\tACCURACY\tREASON")
+	for _, anomaly := range insights.Anomalies {
+		session := anomaly.Session
+		fmt.Fprintf(table, "  %s\t%.1f\t%.1f%%\t%s\n",
+			session.Date.Local().Format("2006-01-02 15:04"), session.WPM, session.Accuracy, anomaly.Reason)
+	}
+	return table.Flush()
+}
+
+// periodLabel names the period starting at start
+func periodLabel(start time.Time, period stats.Period) string {
+	switch period {
+	case stats.PeriodWeek:
+		return "Week of " + start.Format("2006-01-02")
+	case stats.PeriodMonth:
+		return start.Format("2006-01")
+	default:
+		return start.Format("2006-01-02")
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go-touch/internal/stats"
+	"go-touch/internal/types"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeStatsConfig creates a config whose history lives in dir and returns its path
+func writeStatsConfig(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	statsPath := filepath.Join(dir, "user_stats.jsonl")
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("text:\n  source: dummy\nstats:\n  file_dir: \""+statsPath+"\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return configPath, statsPath
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"2025-05-01", time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), false},
+		{"24h", time.Date(2025, 5, 13, 12, 0, 0, 0, time.UTC), false},
+		{"7d", time.Date(2025, 5, 7, 12, 0, 0, 0, time.UTC), false},
+		{"2w", time.Date(2025, 4, 30, 12, 0, 0, 0, time.UTC), false},
+		{"7y", time.Time{}, true},
+		{"d", time.Time{}, true},
+		{"yesterday", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.value, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSince(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseStatsFlags(t *testing.T) {
+	now := time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC)
+
+	opts, err := parseStatsFlags([]string{"--since", "7d", "--mode", "Words", "--source", "llm", "--by", "week", "--no-idle", "--json"}, now, io.Discard)
+	if err != nil {
+		t.Fatalf("parseStatsFlags() unexpected error: %v", err)
+	}
+	if opts.filter.Mode != types.ModeWords || opts.filter.Source != "llm" || opts.by != stats.PeriodWeek || !opts.filter.SkipIdle || !opts.json {
+		t.Errorf("parseStatsFlags() = %+v, want words, llm, week, no idle and json", opts)
+	}
+	if !opts.filter.Since.Equal(now.AddDate(0, 0, -7)) {
+		t.Errorf("parseStatsFlags() since = %v, want a week ago", opts.filter.Since)
+	}
+
+	for _, args := range [][]string{{"--mode", "marathon"}, {"--by", "year"}, {"--since", "soon"}, {"extra"}, {"--by", "day", "--bests"}, {"--insights", "--bests"}} {
+		if _, err := parseStatsFlags(args, now, io.Discard); err == nil {
+			t.Errorf("parseStatsFlags(%q) expected an error", args)
+		}
+	}
+}
+
+func TestPrintStats_Table(t *testing.T) {
+	sessions := []types.TypingSession{
+		{Date: time.Date(2025, 5, 12, 9, 0, 0, 0, time.Local), WPM: 40, Accuracy: 90, Duration: time.Minute, Mode: types.ModeWords, WordCount: 25, Source: "llm"},
+		{Date: time.Date(2025, 5, 13, 9, 0, 0, 0, time.Local), WPM: 60, Accuracy: 100, Duration: time.Minute},
+	}
+
+	var out bytes.Buffer
+	if err := printStats(&out, statsOptions{}, sessions); err != nil {
+		t.Fatalf("printStats() unexpected error: %v", err)
+	}
+	for _, want := range []string{"DATE", "2025-05-12 09:00", "Words (25)", "llm", "40.0", "2 sessions", "Avg WPM: 50.0"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("printStats() output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := printStats(&out, statsOptions{by: stats.PeriodMonth}, sessions); err != nil {
+		t.Fatalf("printStats() unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "MONTH") || !strings.Contains(out.String(), "2025-05") {
+		t.Errorf("printStats() by month output:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := printStats(&out, statsOptions{bests: true}, append(sessions, types.TypingSession{WPM: 70, Accuracy: 98, Duration: time.Minute})); err != nil {
+		t.Fatalf("printStats() unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "BEST WPM") || !strings.Contains(out.String(), "70.0") || strings.Contains(out.String(), "60.0") {
+		t.Errorf("printStats() bests output:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := printStats(&out, statsOptions{insights: true}, sessions); err != nil {
+		t.Fatalf("printStats() unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Words (25) • llm\n  Insights appear after 8 sessions (1 so far)") {
+		t.Errorf("printStats() insights output:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := printStats(&out, statsOptions{}, nil); err != nil || !strings.Contains(out.String(), "No sessions") {
+		t.Errorf("printStats() with no sessions = %q, %v", out.String(), err)
+	}
+}
+
+func TestRunStats_JSON(t *testing.T) {
+	configPath, statsPath := writeStatsConfig(t, t.TempDir())
+
+	store, err := stats.Open(statsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, session := range []types.TypingSession{
+		{Date: time.Now(), WPM: 40, Source: "llm"},
+		{Date: time.Now(), WPM: 50, Source: "dummy"},
+	} {
+		if err := store.Append(session); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := runStats([]string{"--config", configPath, "--source", "llm", "--json"}, &out); err != nil {
+		t.Fatalf("runStats() unexpected error: %v", err)
+	}
+	var sessions []types.TypingSession
+	if err := json.Unmarshal(out.Bytes(), &sessions); err != nil {
+		t.Fatalf("runStats() printed invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(sessions) != 1 || sessions[0].WPM != 40 {
+		t.Errorf("runStats() sessions = %+v, want the llm session", sessions)
+	}
+}
+
+func TestRunStats_Insights(t *testing.T) {
+	configPath, statsPath := writeStatsConfig(t, t.TempDir())
+
+	store, err := stats.Open(statsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 12; i++ {
+		session := types.TypingSession{Date: time.Now().AddDate(0, 0, i-11), WPM: float32(30 + 2*i), Accuracy: 95}
+		if i == 6 {
+			session.WPM = 5
+		}
+		if err := store.Append(session); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := runStats([]string{"--config", configPath, "--insights", "--target", "60"}, &out); err != nil {
+		t.Fatalf("runStats() unexpected error: %v", err)
+	}
+	for _, want := range []string{"Improving", "Target: 60 WPM in about", "Learning curve over 12 sessions", "REASON", "unusually slow"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("runStats() --insights output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := runStats([]string{"--config", configPath, "--insights", "--json"}, &out); err != nil {
+		t.Fatalf("runStats() unexpected error: %v", err)
+	}
+	var classes []classInsights
+	if err := json.Unmarshal(out.Bytes(), &classes); err != nil {
+		t.Fatalf("runStats() printed invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(classes) != 1 || !classes[0].Insights.Ready || classes[0].Insights.Sessions != 12 || classes[0].Insights.Projection != nil {
+		t.Errorf("runStats() insights = %+v, want a curve over 12 sessions and no target", classes)
+	}
+}
+
+func TestInsightsByClass_MixedModes(t *testing.T) {
+	now := time.Date(2025, 5, 20, 18, 0, 0, 0, time.UTC)
+	var sessions []types.TypingSession
+	for i := 0; i < 10; i++ {
+		// Fast sprints improving and slow long runs holding steady, interleaved
+		sessions = append(sessions,
+			types.TypingSession{Date: now.AddDate(0, 0, i-10), WPM: float32(60 + 3*i), Accuracy: 95, Mode: types.ModeTime, TargetDuration: 15 * time.Second, Source: "dummy"},
+			types.TypingSession{Date: now.AddDate(0, 0, i-10).Add(time.Hour), WPM: 40, Accuracy: 95, Mode: types.ModeTime, TargetDuration: 10 * time.Minute, Source: "dummy"},
+			types.TypingSession{Date: now.AddDate(0, 0, i-10).Add(2 * time.Hour), WPM: 90, Accuracy: 99, Mode: types.ModeWords, WordCount: 10, Source: "dummy", Drill: true},
+		)
+	}
+
+	classes := insightsByClass(sessions, 0, now)
+	if len(classes) != 2 {
+		t.Fatalf("insightsByClass() = %d classes, want the sprints and the long runs without drills", len(classes))
+	}
+	sprints, runs := classes[0].Insights, classes[1].Insights
+	if classes[0].Key.Setting != "15s" || sprints.Sessions != 10 || sprints.Trend < 0.5 {
+		t.Errorf("sprints = %+v, %+v, want 10 improving sessions", classes[0].Key, sprints)
+	}
+	if classes[1].Key.Setting != "10m0s" || runs.Sessions != 10 || math.Abs(runs.Trend) > 0.01 || len(runs.Anomalies) != 0 {
+		t.Errorf("long runs = %+v, %+v, want 10 steady sessions", classes[1].Key, runs)
+	}
+
+	var out bytes.Buffer
+	if err := printStats(&out, statsOptions{insights: true}, sessions); err != nil {
+		t.Fatalf("printStats() unexpected error: %v", err)
+	}
+	for _, want := range []string{"Time (15 seconds) • dummy", "Improving", "Time (10 minutes) • dummy", "Holding steady"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("printStats() insights output missing %q:\n%s", want, out.String())
+		}
+	}
+}